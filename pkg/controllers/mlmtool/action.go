@@ -0,0 +1,115 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/action"
+	_action "mlmtool/pkg/usecases/action"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "action to list, show, cancel and reschedule scheduled actions",
+	Long: `action works on the scheduled actions: list the actions in a state, show an action with the result and output
+per system, cancel actions, or reschedule actions on the failed systems`,
+}
+
+var actionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the scheduled actions in the given state",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Command = _action.CommandList
+		inputData.State, _ = cmd.Flags().GetString("state")
+		return executeAction(inputData)
+	},
+}
+
+var actionShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "show the given action with the result and output per system",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Command = _action.CommandShow
+		id, _ := cmd.Flags().GetInt("id")
+		inputData.ActionIDs = []int{id}
+		return executeAction(inputData)
+	},
+}
+
+var actionCancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "cancel the given actions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Command = _action.CommandCancel
+		inputData.ActionIDs, _ = cmd.Flags().GetIntSlice("ids")
+		return executeAction(inputData)
+	},
+}
+
+var actionRescheduleCmd = &cobra.Command{
+	Use:   "reschedule",
+	Short: "reschedule the given actions on the failed systems",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Command = _action.CommandReschedule
+		inputData.ActionIDs, _ = cmd.Flags().GetIntSlice("ids")
+		inputData.AllSystems, _ = cmd.Flags().GetBool("all")
+		return executeAction(inputData)
+	},
+}
+
+// init initializes the actionCmd and its subcommands by adding them to the rootCmd and defining their flags.
+func init() {
+	rootCmd.AddCommand(actionCmd)
+	actionCmd.AddCommand(actionListCmd, actionShowCmd, actionCancelCmd, actionRescheduleCmd)
+	var state string
+	var id int
+	var ids []int
+	var allSystems bool
+
+	actionListCmd.Flags().StringVarP(&state, "state", "s", _action.StateAll,
+		"state of the actions: all, inprogress, failed, completed or archived")
+
+	actionShowCmd.Flags().IntVarP(&id, "id", "i", 0,
+		"id of the action. Required")
+	_ = actionShowCmd.MarkFlagRequired("id")
+
+	for _, cmd := range []*cobra.Command{actionCancelCmd, actionRescheduleCmd} {
+		cmd.Flags().IntSliceVarP(&ids, "ids", "i", nil,
+			"ids of the actions. Can be given multiple times or comma separated. Required")
+		_ = cmd.MarkFlagRequired("ids")
+	}
+	actionRescheduleCmd.Flags().BoolVarP(&allSystems, "all", "a", false,
+		"Reschedule on all systems of the actions, instead of only the failed systems")
+}
+
+// executeAction initializes and executes the requested command on the scheduled actions.
+// Returns an error if any step, including SUSE Manager login or the command itself fails.
+func executeAction(inputData _model.InputData) (err error) {
+	logger.Debug("action started")
+	logger.Debug("params: ")
+	logger.Debug("   command: ", inputData.Command)
+	logger.Debug("   state: ", inputData.State)
+	logger.Debug("   ids: ", inputData.ActionIDs)
+	logger.Debug("   all: ", inputData.AllSystems)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	action := _action.NewAction(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return action.Action()
+}
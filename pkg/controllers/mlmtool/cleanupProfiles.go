@@ -0,0 +1,59 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/cleanupProfiles"
+	_cleanupProfiles "mlmtool/pkg/usecases/cleanupProfiles"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var cleanupProfilesCmd = &cobra.Command{
+	Use:   "cleanupProfiles",
+	Short: "cleanupProfiles to remove stale autoinstallation profiles",
+	Long: `cleanupProfiles removes the autoinstallation profiles whose distribution, or the channel of the distribution,
+no longer exists, or which are not one of the known autoyast types. The profiles are removed after confirmation`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Yes, _ = cmd.Flags().GetBool("yes")
+		inputData.DryRun, _ = cmd.Flags().GetBool("dry-run")
+		return executeCleanupProfiles(inputData)
+	},
+}
+
+// init initializes the cleanupProfilesCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(cleanupProfilesCmd)
+	var yes, dryRun bool
+	cleanupProfilesCmd.Flags().BoolVarP(&yes, "yes", "y", false,
+		"Remove the profiles without asking for confirmation")
+	cleanupProfilesCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false,
+		"Only show the profiles that would be removed")
+	cleanupProfilesCmd.MarkFlagsMutuallyExclusive("yes", "dry-run")
+}
+
+// executeCleanupProfiles initializes and executes the removal of the stale autoinstallation profiles.
+// Returns an error if any step, including SUSE Manager login or the removal itself fails.
+func executeCleanupProfiles(inputData _model.InputData) (err error) {
+	logger.Debug("cleanupProfiles started")
+	logger.Debug("params: ")
+	logger.Debug("   yes: ", inputData.Yes)
+	logger.Debug("   dry-run: ", inputData.DryRun)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	cleanupProfiles := _cleanupProfiles.NewCleanupProfiles(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return cleanupProfiles.CleanupProfiles()
+}
@@ -0,0 +1,66 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/createAutoyastProfile"
+	_createAutoyastProfile "mlmtool/pkg/usecases/createAutoyastProfile"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/consts"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var createAutoyastProfileCmd = &cobra.Command{
+	Use:   "createAutoyastProfile",
+	Short: "createAutoyastProfile to (re)create the autoyast profiles from templates",
+	Long: `createAutoyastProfile renders the template <type>.xml from the autoyast dir for every autoyast type, creates
+the distribution of the type from osreleasedata.json when missing, and imports the profile with its variables.
+Existing profiles are replaced. The templates can use {{.Server}}, {{.Type}}, {{.OsLabel}}, {{.Distribution}}
+and {{.ParentChannel}}`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Types, _ = cmd.Flags().GetStringSlice("types")
+		inputData.Dir, _ = cmd.Flags().GetString("dir")
+		inputData.OsDataFile, _ = cmd.Flags().GetString("osdata")
+		return executeCreateAutoyastProfile(inputData)
+	},
+}
+
+// init initializes the createAutoyastProfileCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(createAutoyastProfileCmd)
+	var dir, osData string
+	var types []string
+	createAutoyastProfileCmd.Flags().StringSliceVarP(&types, "types", "t", nil,
+		"autoyast types to create. Default all known types")
+	createAutoyastProfileCmd.Flags().StringVarP(&dir, "dir", "d", consts.DefaultAutoyastDir,
+		"directory with the autoyast templates")
+	createAutoyastProfileCmd.Flags().StringVarP(&osData, "osdata", "o", "",
+		"file with the os release data. Default osreleasedata.json in the scripts dir")
+}
+
+// executeCreateAutoyastProfile initializes and executes the creation of the autoyast profiles.
+// Returns an error if any step, including SUSE Manager login or the creation itself fails.
+func executeCreateAutoyastProfile(inputData _model.InputData) (err error) {
+	logger.Debug("createAutoyastProfile started")
+	logger.Debug("params: ")
+	logger.Debug("   types: ", inputData.Types)
+	logger.Debug("   dir: ", inputData.Dir)
+	logger.Debug("   osdata: ", inputData.OsDataFile)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	createAutoyastProfile := _createAutoyastProfile.NewCreateAutoyastProfile(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return createAutoyastProfile.CreateAutoyastProfile()
+}
@@ -0,0 +1,68 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/createImageProfile"
+	_createImageProfile "mlmtool/pkg/usecases/createImageProfile"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var createImageProfileCmd = &cobra.Command{
+	Use:   "createImageProfile",
+	Short: "createImageProfile to create the kiwi and dockerfile image profiles from a YAML file",
+	Long: `createImageProfile creates the image profiles listed in the given YAML file. Profiles that exist with the
+same settings are left alone, profiles with other settings are only recreated with --replace. The file looks like:
+
+profiles:
+  - label: slmicro-55
+    type: kiwi
+    store: SUSE Manager OS Image Store
+    path: https://git.example.com/images/slmicro.git#main:slmicro-55
+    activation_key: 1-slmicro-55
+    kiwi_options: --profile Default`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.File, _ = cmd.Flags().GetString("file")
+		inputData.Replace, _ = cmd.Flags().GetBool("replace")
+		return executeCreateImageProfile(inputData)
+	},
+}
+
+// init initializes the createImageProfileCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(createImageProfileCmd)
+	var file string
+	var replace bool
+	createImageProfileCmd.Flags().StringVarP(&file, "file", "f", "",
+		"YAML file with the image profiles. Required")
+	createImageProfileCmd.Flags().BoolVarP(&replace, "replace", "r", false,
+		"Recreate the profiles that exist with other settings")
+	_ = createImageProfileCmd.MarkFlagRequired("file")
+}
+
+// executeCreateImageProfile initializes and executes the creation of the image profiles.
+// Returns an error if any step, including SUSE Manager login or the creation itself fails.
+func executeCreateImageProfile(inputData _model.InputData) (err error) {
+	logger.Debug("createImageProfile started")
+	logger.Debug("params: ")
+	logger.Debug("   file: ", inputData.File)
+	logger.Debug("   replace: ", inputData.Replace)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	createImageProfile := _createImageProfile.NewCreateImageProfile(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return createImageProfile.CreateImageProfile()
+}
@@ -0,0 +1,75 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/createRepos"
+	_createRepos "mlmtool/pkg/usecases/createRepos"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var createReposCmd = &cobra.Command{
+	Use:   "createRepos",
+	Short: "createRepos to create custom channels and repositories from a YAML file",
+	Long: `createRepos makes the custom channels and repositories match the given YAML file. Missing channels and
+repositories are created, changed urls and GPG settings are updated and the sync of the channels is triggered.
+With --delete, channels and repositories starting with the prefix but not listed are removed. The file looks like:
+
+prefix: custom-
+channels:
+  - label: custom-monitoring-sles15sp5
+    name: Monitoring agents for SLES 15 SP5
+    parent: sle-product-sles15-sp5-pool-x86_64
+    arch: channel-x86_64
+    gpg_key_url: https://repo.example.com/RPM-GPG-KEY
+    gpg_check: true
+    repos:
+      - label: custom-monitoring-sles15sp5
+        url: https://repo.example.com/sles15sp5/
+        type: yum
+        ssl_ca_cert: example-ca`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.File, _ = cmd.Flags().GetString("file")
+		inputData.Delete, _ = cmd.Flags().GetBool("delete")
+		return executeCreateRepos(inputData)
+	},
+}
+
+// init initializes the createReposCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(createReposCmd)
+	var file string
+	var deleteUnlisted bool
+	createReposCmd.Flags().StringVarP(&file, "file", "f", "",
+		"YAML file with the custom channels and repositories. Required")
+	createReposCmd.Flags().BoolVarP(&deleteUnlisted, "delete", "", false,
+		"Delete the channels and repositories starting with the prefix that are not listed in the file")
+	_ = createReposCmd.MarkFlagRequired("file")
+}
+
+// executeCreateRepos initializes and executes the reconciliation of the custom channels and repositories.
+// Returns an error if any step, including SUSE Manager login or the reconciliation itself fails.
+func executeCreateRepos(inputData _model.InputData) (err error) {
+	logger.Debug("createRepos started")
+	logger.Debug("params: ")
+	logger.Debug("   file: ", inputData.File)
+	logger.Debug("   delete: ", inputData.Delete)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	createRepos := _createRepos.NewCreateRepos(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return createRepos.CreateRepos()
+}
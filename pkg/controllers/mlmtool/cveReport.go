@@ -0,0 +1,73 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/cveReport"
+	_cveReport "mlmtool/pkg/usecases/cveReport"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var cveReportCmd = &cobra.Command{
+	Use:   "cveReport",
+	Short: "cveReport for the given CVEs",
+	Long: `cveReport lists for the given CVEs every affected system, the patch that fixes it, the patch status on the system
+and whether the patch is available in each content lifecycle environment. The report is written as table, csv or json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cves, _ := cmd.Flags().GetStringSlice("cve")
+		file, _ := cmd.Flags().GetString("file")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		return executeCveReport(cves, file, format, output)
+	},
+}
+
+// init initializes the cveReportCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(cveReportCmd)
+	var cves []string
+	var file, format, output string
+	cveReportCmd.Flags().StringSliceVarP(&cves, "cve", "e", nil,
+		"CVE to report on, like CVE-2024-3094. Can be given multiple times or comma separated")
+	cveReportCmd.Flags().StringVarP(&file, "file", "f", "",
+		"file containing the CVEs to report on, one per line")
+	cveReportCmd.Flags().StringVarP(&format, "format", "F", _cveReport.FormatTable,
+		"format of the report: table, csv or json")
+	cveReportCmd.Flags().StringVarP(&output, "output", "o", "",
+		"file to write the report to. Default the report is written to stdout")
+	cveReportCmd.MarkFlagsOneRequired("cve", "file")
+}
+
+// executeCveReport initializes and executes the process to create the CVE report.
+// Returns an error if any step, including SUSE Manager login or writing the report fails.
+func executeCveReport(cves []string, file string, format string, output string) (err error) {
+	logger.Debug("cveReport started")
+	logger.Debug("params: ")
+	logger.Debug("   cve: ", cves)
+	logger.Debug("   file: ", file)
+	logger.Debug("   format: ", format)
+	logger.Debug("   output: ", output)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Cves = cves
+	inputData.File = file
+	inputData.Format = format
+	inputData.Output = output
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	cveReport := _cveReport.NewCveReport(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return cveReport.CveReport()
+}
@@ -0,0 +1,72 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/doPackageUpdate"
+	_doPackageUpdate "mlmtool/pkg/usecases/doPackageUpdate"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var doPackageUpdateCmd = &cobra.Command{
+	Use:   "doPackageUpdate",
+	Short: "doPackageUpdate to upgrade specific packages on the given systems or group",
+	Long: `doPackageUpdate upgrades the given packages on the systems that have an older version installed. The systems
+are updated in parallel batches. Transactional systems are updated with transactional-update and rebooted.
+The versions before and after the update are printed per system`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Packages, _ = cmd.Flags().GetStringSlice("packages")
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		inputData.Systems, _ = cmd.Flags().GetStringSlice("systems")
+		inputData.BatchSize, _ = cmd.Flags().GetInt("batch")
+		return executeDoPackageUpdate(inputData)
+	},
+}
+
+// init initializes the doPackageUpdateCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(doPackageUpdateCmd)
+	var group string
+	var packages, systems []string
+	var batchSize int
+	doPackageUpdateCmd.Flags().StringSliceVarP(&packages, "packages", "p", nil,
+		"names of the packages to upgrade. Can be given multiple times or comma separated. Required")
+	doPackageUpdateCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group")
+	doPackageUpdateCmd.Flags().StringSliceVarP(&systems, "systems", "s", nil,
+		"names of the systems. Can be given multiple times or comma separated")
+	doPackageUpdateCmd.Flags().IntVarP(&batchSize, "batch", "n", 10,
+		"number of systems updated in parallel")
+	_ = doPackageUpdateCmd.MarkFlagRequired("packages")
+	doPackageUpdateCmd.MarkFlagsOneRequired("group", "systems")
+	doPackageUpdateCmd.MarkFlagsMutuallyExclusive("group", "systems")
+}
+
+// executeDoPackageUpdate initializes and executes the upgrade of the packages.
+// Returns an error if any step, including SUSE Manager login or the upgrade itself fails.
+func executeDoPackageUpdate(inputData _model.InputData) (err error) {
+	logger.Debug("doPackageUpdate started")
+	logger.Debug("params: ")
+	logger.Debug("   packages: ", inputData.Packages)
+	logger.Debug("   group: ", inputData.Group)
+	logger.Debug("   systems: ", inputData.Systems)
+	logger.Debug("   batch: ", inputData.BatchSize)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	doPackageUpdate := _doPackageUpdate.NewDoPackageUpdate(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return doPackageUpdate.DoPackageUpdate()
+}
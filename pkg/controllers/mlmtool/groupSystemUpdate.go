@@ -0,0 +1,64 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/groupSystemUpdate"
+	_groupSystemUpdate "mlmtool/pkg/usecases/groupSystemUpdate"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var groupSystemUpdateCmd = &cobra.Command{
+	Use:   "groupSystemUpdate",
+	Short: "groupSystemUpdate for all systems in the given group",
+	Long: `groupSystemUpdate applies all relevant patches and package updates to all active systems in the given group.
+Up to maintenance.max_parallel systems are updated at once, started maintenance.wait_between_systems seconds apart.
+Systems listed in maintenance.exclude_for_patch are skipped`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		group, _ := cmd.Flags().GetString("group")
+		reboot, _ := cmd.Flags().GetBool("reboot")
+		return executeGroupSystemUpdate(group, reboot)
+	},
+}
+
+// init initializes the groupSystemUpdateCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(groupSystemUpdateCmd)
+	var group string
+	var reboot bool
+	groupSystemUpdateCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group to be updated. Required")
+	groupSystemUpdateCmd.Flags().BoolVarP(&reboot, "reboot", "r", false,
+		"Reboot each system after it has been updated")
+	_ = groupSystemUpdateCmd.MarkFlagRequired("group")
+}
+
+// executeGroupSystemUpdate initializes and executes the process to update all systems in a group.
+// Returns an error if any step, including SUSE Manager login or updating the systems fails.
+func executeGroupSystemUpdate(group string, reboot bool) (err error) {
+	logger.Debug("groupSystemUpdate started")
+	logger.Debug("params: ")
+	logger.Debug("   group: ", group)
+	logger.Debug("   reboot: ", reboot)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Group = group
+	inputData.Reboot = reboot
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	groupSystemUpdate := _groupSystemUpdate.NewGroupSystemUpdate(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return groupSystemUpdate.GroupSystemUpdate()
+}
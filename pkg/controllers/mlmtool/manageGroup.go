@@ -0,0 +1,167 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/manageGroup"
+	_manageGroup "mlmtool/pkg/usecases/manageGroup"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var manageGroupCmd = &cobra.Command{
+	Use:   "manageGroup",
+	Short: "manageGroup to create, delete, fill and sync system groups",
+	Long: `manageGroup manages the system groups: create or delete a group, add or remove systems by name or regular expression,
+list the membership, or sync the membership of the groups declaratively from a YAML file`,
+}
+
+var manageGroupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "create the given system group",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionCreate
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		inputData.Description, _ = cmd.Flags().GetString("description")
+		return executeManageGroup(inputData)
+	},
+}
+
+var manageGroupDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "delete the given system group",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionDelete
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		return executeManageGroup(inputData)
+	},
+}
+
+var manageGroupAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "add systems, by name or regular expression, to the given system group",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionAdd
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		inputData.Systems, _ = cmd.Flags().GetStringSlice("systems")
+		inputData.Regex, _ = cmd.Flags().GetString("regex")
+		return executeManageGroup(inputData)
+	},
+}
+
+var manageGroupRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "remove systems, by name or regular expression, from the given system group",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionRemove
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		inputData.Systems, _ = cmd.Flags().GetStringSlice("systems")
+		inputData.Regex, _ = cmd.Flags().GetString("regex")
+		return executeManageGroup(inputData)
+	},
+}
+
+var manageGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the systems in the given system group, or all system groups",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionList
+		inputData.Group, _ = cmd.Flags().GetString("group")
+		return executeManageGroup(inputData)
+	},
+}
+
+var manageGroupSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "sync the membership of the system groups from the given YAML file",
+	Long: `sync makes the system groups match the given YAML file. Missing groups are created, missing systems are added
+and systems not listed are removed. Groups not listed in the file are left alone. The file looks like:
+
+groups:
+  webservers:
+    description: all webservers
+    systems:
+      - lx0001.example.com
+      - lx0002`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Action = _manageGroup.ActionSync
+		inputData.File, _ = cmd.Flags().GetString("file")
+		inputData.DryRun, _ = cmd.Flags().GetBool("dryrun")
+		return executeManageGroup(inputData)
+	},
+}
+
+// init initializes the manageGroupCmd and its subcommands by adding them to the rootCmd and defining their flags.
+func init() {
+	rootCmd.AddCommand(manageGroupCmd)
+	manageGroupCmd.AddCommand(manageGroupCreateCmd, manageGroupDeleteCmd, manageGroupAddCmd, manageGroupRemoveCmd, manageGroupListCmd, manageGroupSyncCmd)
+	var group, description, regex, file string
+	var systems []string
+	var dryRun bool
+
+	manageGroupCreateCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group. Required")
+	manageGroupCreateCmd.Flags().StringVarP(&description, "description", "d", "",
+		"description of the system group. Default the name of the group")
+	_ = manageGroupCreateCmd.MarkFlagRequired("group")
+
+	manageGroupDeleteCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group. Required")
+	_ = manageGroupDeleteCmd.MarkFlagRequired("group")
+
+	for _, cmd := range []*cobra.Command{manageGroupAddCmd, manageGroupRemoveCmd} {
+		cmd.Flags().StringVarP(&group, "group", "g", "",
+			"name of the system group. Required")
+		cmd.Flags().StringSliceVarP(&systems, "systems", "s", nil,
+			"names of the systems. Can be given multiple times or comma separated")
+		cmd.Flags().StringVarP(&regex, "regex", "e", "",
+			"regular expression matching the names of the systems")
+		_ = cmd.MarkFlagRequired("group")
+		cmd.MarkFlagsOneRequired("systems", "regex")
+	}
+
+	manageGroupListCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group. Default all system groups are listed")
+
+	manageGroupSyncCmd.Flags().StringVarP(&file, "file", "f", "",
+		"YAML file with the desired system groups. Required")
+	manageGroupSyncCmd.Flags().BoolVarP(&dryRun, "dryrun", "d", false,
+		"Only show the changes, without applying them")
+	_ = manageGroupSyncCmd.MarkFlagRequired("file")
+}
+
+// executeManageGroup initializes and executes the requested action on the system groups.
+// Returns an error if any step, including SUSE Manager login or the action itself fails.
+func executeManageGroup(inputData _model.InputData) (err error) {
+	logger.Debug("manageGroup started")
+	logger.Debug("params: ")
+	logger.Debug("   action: ", inputData.Action)
+	logger.Debug("   group: ", inputData.Group)
+	logger.Debug("   description: ", inputData.Description)
+	logger.Debug("   systems: ", inputData.Systems)
+	logger.Debug("   regex: ", inputData.Regex)
+	logger.Debug("   file: ", inputData.File)
+	logger.Debug("   dryrun: ", inputData.DryRun)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	manageGroup := _manageGroup.NewManageGroup(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return manageGroup.ManageGroup()
+}
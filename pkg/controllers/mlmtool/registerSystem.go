@@ -0,0 +1,79 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/registerSystem"
+	_registerSystem "mlmtool/pkg/usecases/registerSystem"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var registerSystemCmd = &cobra.Command{
+	Use:   "registerSystem",
+	Short: "registerSystem for a new system",
+	Long: `registerSystem registers a new system with the given activation key, or with the activation key matching the os label
+from osreleasedata.json, which is created when not present. The bootstrap command to run on the system is printed and
+the system is waited for, after which it is added to the given groups and the given formulas are assigned`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Server, _ = cmd.Flags().GetString("server")
+		inputData.ActivationKey, _ = cmd.Flags().GetString("activationkey")
+		inputData.OsLabel, _ = cmd.Flags().GetString("os")
+		inputData.OsDataFile, _ = cmd.Flags().GetString("osdata")
+		inputData.Groups, _ = cmd.Flags().GetStringSlice("groups")
+		inputData.Formulas, _ = cmd.Flags().GetStringSlice("formulas")
+		return executeRegisterSystem(inputData)
+	},
+}
+
+// init initializes the registerSystemCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(registerSystemCmd)
+	var server, activationKey, osLabel, osData string
+	var groups, formulas []string
+	registerSystemCmd.Flags().StringVarP(&server, "server", "s", "",
+		"hostname of the system to be registered. Required")
+	registerSystemCmd.Flags().StringVarP(&activationKey, "activationkey", "a", "",
+		"activation key to register the system with")
+	registerSystemCmd.Flags().StringVarP(&osLabel, "os", "o", "",
+		"os label from osreleasedata.json, like s155, to pick or create the activation key")
+	registerSystemCmd.Flags().StringVarP(&osData, "osdata", "d", "",
+		"path of osreleasedata.json. Default it is read from dirs.scripts_dir")
+	registerSystemCmd.Flags().StringSliceVarP(&groups, "groups", "g", nil,
+		"system groups the system is added to. Can be given multiple times or comma separated")
+	registerSystemCmd.Flags().StringSliceVarP(&formulas, "formulas", "f", nil,
+		"formulas assigned to the system. Can be given multiple times or comma separated")
+	_ = registerSystemCmd.MarkFlagRequired("server")
+	registerSystemCmd.MarkFlagsOneRequired("activationkey", "os")
+	registerSystemCmd.MarkFlagsMutuallyExclusive("activationkey", "os")
+}
+
+// executeRegisterSystem initializes and executes the process to register a new system.
+// Returns an error if any step, including SUSE Manager login or the registration fails.
+func executeRegisterSystem(inputData _model.InputData) (err error) {
+	logger.Debug("registerSystem started")
+	logger.Debug("params: ")
+	logger.Debug("   server: ", inputData.Server)
+	logger.Debug("   activationkey: ", inputData.ActivationKey)
+	logger.Debug("   os: ", inputData.OsLabel)
+	logger.Debug("   osdata: ", inputData.OsDataFile)
+	logger.Debug("   groups: ", inputData.Groups)
+	logger.Debug("   formulas: ", inputData.Formulas)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	registerSystem := _registerSystem.NewRegisterSystem(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return registerSystem.RegisterSystem()
+}
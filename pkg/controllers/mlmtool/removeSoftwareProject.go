@@ -0,0 +1,57 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/removeSoftwareProject"
+	_removeSoftwareProject "mlmtool/pkg/usecases/removeSoftwareProject"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var removeSoftwareProjectCmd = &cobra.Command{
+	Use:   "removeSoftwareProject",
+	Short: "removeSoftwareProject removes the given content lifecycle project",
+	Long: `removeSoftwareProject removes the given content lifecycle project, including all environments, sources and filters.
+The project will not be removed when an activation key or system is still using one of the channels of the project`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		return executeRemoveSoftwareProject(project)
+	},
+}
+
+// init initializes the removeSoftwareProjectCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(removeSoftwareProjectCmd)
+	var project string
+	removeSoftwareProjectCmd.Flags().StringVarP(&project, "project", "p", "",
+		"name of the project to be removed. Required")
+	_ = removeSoftwareProjectCmd.MarkFlagRequired("project")
+}
+
+// executeRemoveSoftwareProject initializes and executes the process to remove a software project.
+// Returns an error if any step, including SUSE Manager login or removing the project fails.
+func executeRemoveSoftwareProject(project string) (err error) {
+	logger.Debug("removeSoftwareProject started")
+	logger.Debug("params: ")
+	logger.Debug("   project: ", project)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Project = project
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	removeSoftwareProject := _removeSoftwareProject.NewRemoveSoftwareProject(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return removeSoftwareProject.RemoveSoftwareProject()
+}
@@ -1,15 +1,22 @@
 package mlmtool
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	model "mlmtool/pkg/models/inputfile"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
 
 	log "mlmtool/pkg/util/logger"
 	_ "mlmtool/pkg/util/readconfig"
 	ri "mlmtool/pkg/util/readconfig"
+	"mlmtool/pkg/util/rest"
 	_ "mlmtool/pkg/util/returnCodes"
+	"mlmtool/pkg/util/suman"
 
 	"github.com/spf13/cobra"
 )
@@ -17,6 +24,12 @@ import (
 var cfgFile string
 var AppConfig model.Config
 
+// credentials - user and password of the SUSE Manager server, resolved on first use
+var credentials *suman.Credentials
+
+// sumanProxies - proxies created by the running command, their sessions are logged out on exit
+var sumanProxies []_sumanUseCase.IProxy
+
 var rootCmd = &cobra.Command{
 	Use:   "mlmtool",
 	Short: `mlmtool is a CLI client for core MLM tasks.`,
@@ -41,8 +54,19 @@ the --config flag.`,
 }
 
 // Execute runs the root command of the application and handles any errors by exiting with a non-zero status.
+// SIGINT and SIGTERM cancel the api calls and waits of the command, the open sessions are logged out in any case.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+	for _, sumanProxy := range sumanProxies {
+		sumanProxy.SumanLogoutAll()
+	}
+	if interrupted {
+		fmt.Fprintln(os.Stderr, "mlmtool interrupted, actions already scheduled keep running on the server")
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -68,6 +92,58 @@ func initConfig() error {
 	return nil
 }
 
+// sumanConfig returns the connection settings of the SUSE Manager server. User and password are looked up in the
+// suman.credential_sources once per run. The server is suman.server, or the one of the credential sources if unset,
+// which is then stored as suman.server for the usecases.
+func sumanConfig() (_sumanUseCase.SumanConfig, error) {
+	sumancfg := _sumanUseCase.SumanConfig{
+		Host:     AppConfig.Suman.Server,
+		Insecure: !AppConfig.Suman.SslCertificateCheck,
+	}
+	if credentials == nil {
+		sources, err := suman.NewCredentialSources(AppConfig.Suman)
+		if err != nil {
+			return sumancfg, err
+		}
+		creds, err := suman.ResolveCredentials(sources)
+		if err != nil {
+			return sumancfg, err
+		}
+		credentials = &creds
+	}
+	sumancfg.Login = credentials.User
+	sumancfg.Password = credentials.Password
+	if len(sumancfg.Host) == 0 {
+		sumancfg.Host = credentials.Server
+		AppConfig.Suman.Server = credentials.Server
+	}
+	return sumancfg, nil
+}
+
+// newSumanProxy returns the proxy of the SUSE Manager server using one pooled connection for all calls. The server
+// certificate is verified against the system CA pool and suman.ca_bundle unless sumancfg.Insecure is set. The api
+// calls are cancelled with the context of the command. The session is kept in suman.session_cache, when set.
+func newSumanProxy(sumancfg *_sumanUseCase.SumanConfig) (_sumanUseCase.IProxy, error) {
+	client, err := rest.NewClient(rest.ClientConfig{
+		Insecure:   sumancfg.Insecure,
+		CABundle:   AppConfig.Suman.CaBundle,
+		Timeout:    time.Duration(AppConfig.Suman.RequestTimeout) * time.Second,
+		RetryCount: AppConfig.Suman.RetryCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx := rootCmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	suseAPI := _sumanUseCase.NewSuseManagerAPIClient(ctx, "rhn/manager/api", client)
+	sessions := _sumanUseCase.NewSessionManager(sumancfg, suseAPI, AppConfig.Suman.SessionCache, time.Duration(AppConfig.Suman.SessionTTL)*time.Second)
+	sumanProxy := _sumanUseCase.NewProxySessions(sumancfg, sessions, AppConfig.Suman.RetryCount)
+	sumanProxies = append(sumanProxies, sumanProxy)
+	return sumanProxy, nil
+}
+
 // PostRun functions seem not to run reliably, at least when I tested
 // See: https://github.com/spf13/cobra/issues/914
 func finalizeRun() {
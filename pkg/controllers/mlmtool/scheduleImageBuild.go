@@ -0,0 +1,69 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/scheduleImageBuild"
+	_scheduleImageBuild "mlmtool/pkg/usecases/scheduleImageBuild"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var scheduleImageBuildCmd = &cobra.Command{
+	Use:   "scheduleImageBuild",
+	Short: "scheduleImageBuild to build the image of an image profile on a build host",
+	Long: `scheduleImageBuild schedules the build of the image of the given profile on the build host and waits until
+the image is built and inspected. The id of the image and the build and inspect status are printed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Profile, _ = cmd.Flags().GetString("profile")
+		inputData.BuildHost, _ = cmd.Flags().GetString("buildhost")
+		inputData.Version, _ = cmd.Flags().GetString("version")
+		inputData.Timeout, _ = cmd.Flags().GetInt("timeout")
+		return executeScheduleImageBuild(inputData)
+	},
+}
+
+// init initializes the scheduleImageBuildCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(scheduleImageBuildCmd)
+	var profile, buildHost, version string
+	var timeout int
+	scheduleImageBuildCmd.Flags().StringVarP(&profile, "profile", "p", "",
+		"label of the image profile. Required")
+	scheduleImageBuildCmd.Flags().StringVarP(&buildHost, "buildhost", "b", "",
+		"name of the build host. Required")
+	scheduleImageBuildCmd.Flags().StringVarP(&version, "version", "v", "",
+		"version of the image. Default the version from the profile")
+	scheduleImageBuildCmd.Flags().IntVarP(&timeout, "timeout", "t", 0,
+		"seconds to wait for the build. Default the timeout from the config")
+	_ = scheduleImageBuildCmd.MarkFlagRequired("profile")
+	_ = scheduleImageBuildCmd.MarkFlagRequired("buildhost")
+}
+
+// executeScheduleImageBuild initializes and executes the build of the image.
+// Returns an error if any step, including SUSE Manager login or the build itself fails.
+func executeScheduleImageBuild(inputData _model.InputData) (err error) {
+	logger.Debug("scheduleImageBuild started")
+	logger.Debug("params: ")
+	logger.Debug("   profile: ", inputData.Profile)
+	logger.Debug("   buildhost: ", inputData.BuildHost)
+	logger.Debug("   version: ", inputData.Version)
+	logger.Debug("   timeout: ", inputData.Timeout)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	scheduleImageBuild := _scheduleImageBuild.NewScheduleImageBuild(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return scheduleImageBuild.ScheduleImageBuild()
+}
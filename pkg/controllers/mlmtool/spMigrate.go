@@ -0,0 +1,70 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/spMigrate"
+	_spMigrate "mlmtool/pkg/usecases/spMigrate"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var spMigrateCmd = &cobra.Command{
+	Use:   "spMigrate",
+	Short: "spMigrate for the given system or all systems in the given group",
+	Long: `spMigrate performs a service pack migration of the given system or of all active systems in the given group.
+The target is determined with maintenance.sp_migration_project, maintenance.sp_migration and maintenance.exception_sp.
+The migration is run as dry run first, followed by the real migration and a reboot, after which the new base channel is verified`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server, _ := cmd.Flags().GetString("server")
+		group, _ := cmd.Flags().GetString("group")
+		dryRun, _ := cmd.Flags().GetBool("dryrun")
+		return executeSpMigrate(server, group, dryRun)
+	},
+}
+
+// init initializes the spMigrateCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(spMigrateCmd)
+	var server, group string
+	var dryRun bool
+	spMigrateCmd.Flags().StringVarP(&server, "server", "s", "",
+		"name of the system to be migrated")
+	spMigrateCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group to be migrated")
+	spMigrateCmd.Flags().BoolVarP(&dryRun, "dryrun", "d", false,
+		"Only perform the dry run of the migration")
+	spMigrateCmd.MarkFlagsOneRequired("server", "group")
+	spMigrateCmd.MarkFlagsMutuallyExclusive("server", "group")
+}
+
+// executeSpMigrate initializes and executes the process to perform a service pack migration.
+// Returns an error if any step, including SUSE Manager login or a fatal migration step fails.
+func executeSpMigrate(server string, group string, dryRun bool) (err error) {
+	logger.Debug("spMigrate started")
+	logger.Debug("params: ")
+	logger.Debug("   server: ", server)
+	logger.Debug("   group: ", group)
+	logger.Debug("   dryrun: ", dryRun)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Server = server
+	inputData.Group = group
+	inputData.DryRun = dryRun
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	spMigrate := _spMigrate.NewSpMigrate(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return spMigrate.SpMigrate()
+}
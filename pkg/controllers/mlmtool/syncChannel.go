@@ -0,0 +1,73 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/syncChannel"
+	_syncChannel "mlmtool/pkg/usecases/syncChannel"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var syncChannelCmd = &cobra.Command{
+	Use:   "syncChannel",
+	Short: "syncChannel for given software channel",
+	Long:  `syncChannel triggers a repository sync for the given software channel and optionally all its child channels`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channel, _ := cmd.Flags().GetString("channel")
+		withChildren, _ := cmd.Flags().GetBool("with-children")
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetInt("timeout")
+		return executeSyncChannel(channel, withChildren, wait, timeout)
+	},
+}
+
+// init initializes the syncChannelCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(syncChannelCmd)
+	var channel string
+	var withChildren, wait bool
+	var timeout int
+	syncChannelCmd.Flags().StringVarP(&channel, "channel", "l", "",
+		"label of the channel to be synced. Required")
+	syncChannelCmd.Flags().BoolVarP(&withChildren, "with-children", "r", false,
+		"Also sync all child channels of the given channel")
+	syncChannelCmd.Flags().BoolVarP(&wait, "wait", "w", false,
+		"Wait with finish, until sync is completed. Otherwise the sync runs in the background")
+	syncChannelCmd.Flags().IntVarP(&timeout, "timeout", "t", 0,
+		"Maximum time in seconds to wait for the sync to complete. Default is the suman timeout from the config")
+	_ = syncChannelCmd.MarkFlagRequired("channel")
+}
+
+// executeSyncChannel initializes and executes the process to sync a software channel.
+// Returns an error if any step, including SUSE Manager login or syncing one of the channels fails.
+func executeSyncChannel(channel string, withChildren bool, wait bool, timeout int) (err error) {
+	logger.Debug("syncChannel started")
+	logger.Debug("params: ")
+	logger.Debug("   channel: ", channel)
+	logger.Debug("   withChildren: ", withChildren)
+	logger.Debug("   wait: ", wait)
+	logger.Debug("   timeout: ", timeout)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Channel = channel
+	inputData.WithChildren = withChildren
+	inputData.Wait = wait
+	inputData.Timeout = timeout
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	syncChannel := _syncChannel.NewSyncChannel(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return syncChannel.SyncChannel()
+}
@@ -0,0 +1,68 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/syncEnvironment"
+	_syncEnvironment "mlmtool/pkg/usecases/syncEnvironment"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var syncEnvironmentCmd = &cobra.Command{
+	Use:   "syncEnvironment",
+	Short: "syncEnvironment for all environments of the given project",
+	Long: `syncEnvironment builds the first environment of the given project and promotes all following environments in order.
+Each environment is waited for until it is built before the next one is promoted`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		until, _ := cmd.Flags().GetString("until")
+		soak, _ := cmd.Flags().GetInt("soak")
+		return executeSyncEnvironment(project, until, soak)
+	},
+}
+
+// init initializes the syncEnvironmentCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(syncEnvironmentCmd)
+	var project, until string
+	var soak int
+	syncEnvironmentCmd.Flags().StringVarP(&project, "project", "p", "",
+		"name of the project to be synced. Required")
+	syncEnvironmentCmd.Flags().StringVarP(&until, "until", "u", "",
+		"Last environment to be synced. Default all environments are synced")
+	syncEnvironmentCmd.Flags().IntVarP(&soak, "soak", "s", 0,
+		"Time in seconds to wait between two stages, after the previous stage is built")
+	_ = syncEnvironmentCmd.MarkFlagRequired("project")
+}
+
+// executeSyncEnvironment initializes and executes the process to build and promote all environments of a project.
+// Returns an error if any step, including SUSE Manager login, building or promoting fails.
+func executeSyncEnvironment(project string, until string, soak int) (err error) {
+	logger.Debug("syncEnvironment started")
+	logger.Debug("params: ")
+	logger.Debug("   project: ", project)
+	logger.Debug("   until: ", until)
+	logger.Debug("   soak: ", soak)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Project = project
+	inputData.Until = until
+	inputData.Soak = soak
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	syncEnvironment := _syncEnvironment.NewSyncEnvironment(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return syncEnvironment.SyncEnvironment()
+}
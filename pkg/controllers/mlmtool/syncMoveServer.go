@@ -0,0 +1,104 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	"fmt"
+	_model "mlmtool/pkg/models/syncMoveServer"
+	_syncMoveServer "mlmtool/pkg/usecases/syncMoveServer"
+
+	"mlmtool/pkg/config"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/checksumaserver"
+	"mlmtool/pkg/util/hostname"
+	"mlmtool/pkg/util/logger"
+	"mlmtool/pkg/util/suman"
+
+	"github.com/spf13/cobra"
+)
+
+var syncMoveServerCmd = &cobra.Command{
+	Use:   "syncMoveServer",
+	Short: "syncMoveServer to register or deregister Inter-Server Sync primaries and secondaries",
+	Long: `syncMoveServer configures the Inter-Server Sync of this server. On the primary the given secondary is registered,
+or deregistered with --delete. On a secondary the given primary, default the hubmaster, is registered with its CA
+certificate and made the default, or deregistered with --delete. The credentials are read from the spacecmd config
+on the primary and from the uyuni hub config on a secondary`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Secondary, _ = cmd.Flags().GetString("secondary")
+		inputData.Primary, _ = cmd.Flags().GetString("primary")
+		inputData.CaCert, _ = cmd.Flags().GetString("cacert")
+		inputData.Delete, _ = cmd.Flags().GetBool("delete")
+		credFile, _ := cmd.Flags().GetString("credentials")
+		return executeSyncMoveServer(inputData, credFile)
+	},
+}
+
+// init initializes the syncMoveServerCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(syncMoveServerCmd)
+	var secondary, primary, caCert, credFile string
+	var deleteServer bool
+	syncMoveServerCmd.Flags().StringVarP(&secondary, "secondary", "s", "",
+		"FQDN of the secondary to register. Required on the primary")
+	syncMoveServerCmd.Flags().StringVarP(&primary, "primary", "p", "",
+		"FQDN of the primary to register. Default the hubmaster from the uyuni hub config")
+	syncMoveServerCmd.Flags().StringVarP(&caCert, "cacert", "a", "",
+		"path to the CA certificate of the primary. Required on a secondary")
+	syncMoveServerCmd.Flags().BoolVarP(&deleteServer, "delete", "d", false,
+		"Deregister the secondary or primary instead of registering it")
+	syncMoveServerCmd.Flags().StringVarP(&credFile, "credentials", "f", "",
+		"file with the credentials. Default the spacecmd config on the primary, the uyuni hub config on a secondary")
+}
+
+// executeSyncMoveServer reads the credentials for the role of this server, then initializes and executes the
+// Inter-Server Sync configuration. Returns an error if any step, including SUSE Manager login, fails.
+func executeSyncMoveServer(inputData _model.InputData, credFile string) (err error) {
+	logger.Debug("syncMoveServer started")
+	logger.Debug("params: ")
+	logger.Debug("   secondary: ", inputData.Secondary)
+	logger.Debug("   primary: ", inputData.Primary)
+	logger.Debug("   cacert: ", inputData.CaCert)
+	logger.Debug("   delete: ", inputData.Delete)
+	logger.Debug("   credentials: ", credFile)
+
+	defaults := config.New("syncMoveServer", false)
+	var sumancfg _sumanUseCase.SumanConfig
+	switch {
+	case checksumaserver.Primary():
+		if len(credFile) == 0 {
+			credFile = defaults.FileSpacecmd
+		}
+		sumancfg, err = suman.GetCredentials(credFile)
+		if err != nil {
+			return fmt.Errorf("unable to read credentials from %v: %v", credFile, err)
+		}
+	case checksumaserver.Secondary():
+		if len(credFile) == 0 {
+			credFile = defaults.FileUyuni
+		}
+		sumancfg, err = suman.GetCredentialsUyuni(credFile)
+		if err != nil {
+			return fmt.Errorf("unable to read credentials from %v: %v", credFile, err)
+		}
+		// the uyuni hub config holds the hubmaster, the api of the secondary itself is used
+		if len(inputData.Primary) == 0 {
+			inputData.Primary = sumancfg.Host
+		}
+		sumancfg.Host = hostname.GetHostnameFqdn()
+	default:
+		return fmt.Errorf("this server is marked neither as primary nor as secondary")
+	}
+	sumancfg.Insecure = !AppConfig.Suman.SslCertificateCheck
+	genConfig := AppConfig
+	genConfig.Suman.Server = sumancfg.Host
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	syncMoveServer := _syncMoveServer.NewSyncMoveServer(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, genConfig, inputData)
+
+	return syncMoveServer.SyncMoveServer()
+}
@@ -58,11 +58,10 @@ func executeSyncStage(project string, environment string, backup bool, wait bool
 	logger.Debug("   wait: ", wait)
 	logger.Debug("   description: ", description)
 
-	var sumancfg _sumanUseCase.SumanConfig
-	sumancfg.Login = AppConfig.Suman.User
-	sumancfg.Password = AppConfig.Suman.Password
-	sumancfg.Host = AppConfig.Suman.Server
-	sumancfg.Insecure = AppConfig.Suman.SslCertificateCheck
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
 
 	var inputData _model.InputData
 	inputData.Project = project
@@ -70,8 +69,10 @@ func executeSyncStage(project string, environment string, backup bool, wait bool
 	inputData.Wait = wait
 	inputData.Description = description
 
-	suseAPI := _sumanUseCase.NewSuseManagerAPI("rhn/manager/api", true, AppConfig.Suman.RetryCount)
-	sumanProxyUseCase := _sumanUseCase.NewProxy(&sumancfg, suseAPI, AppConfig.Suman.RetryCount)
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
 	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
 	syncStage := _syncStage.NewSyncStage(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
 
@@ -0,0 +1,69 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/systemHighstate"
+	_systemHighstate "mlmtool/pkg/usecases/systemHighstate"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var systemHighstateCmd = &cobra.Command{
+	Use:   "systemHighstate",
+	Short: "systemHighstate for the given system or all systems in the given group",
+	Long: `systemHighstate applies the highstate on the given system or on all active systems in the given group.
+With --test only a dry run is done. After completion the states that changed, succeeded or failed are printed per system`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server, _ := cmd.Flags().GetString("server")
+		group, _ := cmd.Flags().GetString("group")
+		test, _ := cmd.Flags().GetBool("test")
+		return executeSystemHighstate(server, group, test)
+	},
+}
+
+// init initializes the systemHighstateCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(systemHighstateCmd)
+	var server, group string
+	var test bool
+	systemHighstateCmd.Flags().StringVarP(&server, "server", "s", "",
+		"name of the system to apply the highstate on")
+	systemHighstateCmd.Flags().StringVarP(&group, "group", "g", "",
+		"name of the system group to apply the highstate on")
+	systemHighstateCmd.Flags().BoolVarP(&test, "test", "t", false,
+		"Run the highstate in test mode, showing what would change")
+	systemHighstateCmd.MarkFlagsOneRequired("server", "group")
+	systemHighstateCmd.MarkFlagsMutuallyExclusive("server", "group")
+}
+
+// executeSystemHighstate initializes and executes the process to apply the highstate.
+// Returns an error if any step, including SUSE Manager login or the highstate itself fails.
+func executeSystemHighstate(server string, group string, test bool) (err error) {
+	logger.Debug("systemHighstate started")
+	logger.Debug("params: ")
+	logger.Debug("   server: ", server)
+	logger.Debug("   group: ", group)
+	logger.Debug("   test: ", test)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Server = server
+	inputData.Group = group
+	inputData.Test = test
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	systemHighstate := _systemHighstate.NewSystemHighstate(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return systemHighstate.SystemHighstate()
+}
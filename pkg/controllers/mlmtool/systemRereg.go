@@ -0,0 +1,75 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/systemRereg"
+	_systemRereg "mlmtool/pkg/usecases/systemRereg"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var systemReregCmd = &cobra.Command{
+	Use:   "systemRereg",
+	Short: "systemRereg for the given system or all clients of the given proxy",
+	Long: `systemRereg re-registers the given system, or all clients connected through the given proxy, to the target server or proxy.
+A reactivation key is generated per system and the bootstrap script of the target is run on the system, after which
+the system has to check in again under the same profile. Clients of a proxy are re-registered in batches`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var inputData _model.InputData
+		inputData.Server, _ = cmd.Flags().GetString("server")
+		inputData.Proxy, _ = cmd.Flags().GetString("proxy")
+		inputData.Target, _ = cmd.Flags().GetString("target")
+		inputData.Bootstrap, _ = cmd.Flags().GetString("bootstrap")
+		inputData.BatchSize, _ = cmd.Flags().GetInt("batch")
+		return executeSystemRereg(inputData)
+	},
+}
+
+// init initializes the systemReregCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(systemReregCmd)
+	var server, proxy, target, bootstrap string
+	var batch int
+	systemReregCmd.Flags().StringVarP(&server, "server", "s", "",
+		"name of the system to be re-registered")
+	systemReregCmd.Flags().StringVarP(&proxy, "proxy", "p", "",
+		"name of the proxy of which all clients are re-registered")
+	systemReregCmd.Flags().StringVarP(&target, "target", "t", "",
+		"FQDN of the server or proxy the systems are re-registered to. Required")
+	systemReregCmd.Flags().StringVarP(&bootstrap, "bootstrap", "b", "bootstrap.sh",
+		"name of the bootstrap script in /pub/bootstrap on the target")
+	systemReregCmd.Flags().IntVarP(&batch, "batch", "n", 10,
+		"number of clients of a proxy re-registered at the same time")
+	_ = systemReregCmd.MarkFlagRequired("target")
+	systemReregCmd.MarkFlagsOneRequired("server", "proxy")
+	systemReregCmd.MarkFlagsMutuallyExclusive("server", "proxy")
+}
+
+// executeSystemRereg initializes and executes the process to re-register systems.
+// Returns an error if any step, including SUSE Manager login or re-registering a system fails.
+func executeSystemRereg(inputData _model.InputData) (err error) {
+	logger.Debug("systemRereg started")
+	logger.Debug("params: ")
+	logger.Debug("   server: ", inputData.Server)
+	logger.Debug("   proxy: ", inputData.Proxy)
+	logger.Debug("   target: ", inputData.Target)
+	logger.Debug("   bootstrap: ", inputData.Bootstrap)
+	logger.Debug("   batch: ", inputData.BatchSize)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	systemRereg := _systemRereg.NewSystemRereg(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return systemRereg.SystemRereg()
+}
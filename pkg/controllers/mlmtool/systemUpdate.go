@@ -0,0 +1,65 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/systemUpdate"
+	_systemUpdate "mlmtool/pkg/usecases/systemUpdate"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var systemUpdateCmd = &cobra.Command{
+	Use:   "systemUpdate",
+	Short: "systemUpdate for the given system",
+	Long: `systemUpdate applies all relevant patches and package updates to the given system.
+Before and after the update the scripts pre_update.sh, <server>_pre_update.sh, post_update.sh and <server>_post_update.sh
+from dirs.update_script_dir are run on the system when present. When needed the system is rebooted, after which the
+highstate is applied. Failures are handled according to the error_handling section of the configuration`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server, _ := cmd.Flags().GetString("server")
+		reboot, _ := cmd.Flags().GetBool("reboot")
+		return executeSystemUpdate(server, reboot)
+	},
+}
+
+// init initializes the systemUpdateCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(systemUpdateCmd)
+	var server string
+	var reboot bool
+	systemUpdateCmd.Flags().StringVarP(&server, "server", "s", "",
+		"name of the system to be updated. Required")
+	systemUpdateCmd.Flags().BoolVarP(&reboot, "reboot", "r", false,
+		"Always reboot the system after the update. Otherwise only rebooted when required")
+	_ = systemUpdateCmd.MarkFlagRequired("server")
+}
+
+// executeSystemUpdate initializes and executes the process to update a single system.
+// Returns an error if any step, including SUSE Manager login or a fatal update step fails.
+func executeSystemUpdate(server string, reboot bool) (err error) {
+	logger.Debug("systemUpdate started")
+	logger.Debug("params: ")
+	logger.Debug("   server: ", server)
+	logger.Debug("   reboot: ", reboot)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Server = server
+	inputData.Reboot = reboot
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	systemUpdate := _systemUpdate.NewSystemUpdate(sumanProxyUseCase, suseUseCase, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return systemUpdate.SystemUpdate()
+}
@@ -0,0 +1,65 @@
+// Package mlmtool - this is a collection of tools use for SUSE Manager Operations
+package mlmtool
+
+import (
+	_model "mlmtool/pkg/models/updateBootstrapRepo"
+	_updateBootstrapRepo "mlmtool/pkg/usecases/updateBootstrapRepo"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/cmdexecutor"
+	"mlmtool/pkg/util/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var updateBootstrapRepoCmd = &cobra.Command{
+	Use:   "updateBootstrapRepo",
+	Short: "updateBootstrapRepo for all configured bootstrap repositories",
+	Long: `updateBootstrapRepo recreates the bootstrap repositories configured in bootstrap-repo.repos, using bootstrap-repo.command.
+Each repository is only recreated when the mapped channel exists and has been synced`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetString("repo")
+		return executeUpdateBootstrapRepo(repo)
+	},
+}
+
+// init initializes the updateBootstrapRepoCmd by adding it to the rootCmd and defining its flags.
+func init() {
+	rootCmd.AddCommand(updateBootstrapRepoCmd)
+	var repo string
+	updateBootstrapRepoCmd.Flags().StringVarP(&repo, "repo", "r", "",
+		"Only update the given bootstrap repository, like SLE-15-SP7-x86_64. Default all configured repositories are updated")
+}
+
+// executeUpdateBootstrapRepo initializes and executes the process to recreate the bootstrap repositories.
+// Returns an error if any step, including SUSE Manager login or creating a repository fails.
+func executeUpdateBootstrapRepo(repo string) (err error) {
+	logger.Debug("updateBootstrapRepo started")
+	logger.Debug("params: ")
+	logger.Debug("   repo: ", repo)
+
+	sumancfg, err := sumanConfig()
+	if err != nil {
+		return err
+	}
+
+	var inputData _model.InputData
+	inputData.Repo = repo
+
+	zapLogger, err := zap.NewProduction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zapLogger.Sync() }()
+
+	sumanProxyUseCase, err := newSumanProxy(&sumancfg)
+	if err != nil {
+		return err
+	}
+	suseUseCase := _sumanUseCase.NewSuseManager(sumanProxyUseCase, &sumancfg)
+	cmdExecutor := cmdexecutor.NewCMDExecutor(zapLogger)
+	updateBootstrapRepo := _updateBootstrapRepo.NewUpdateBootstrapRepo(sumanProxyUseCase, suseUseCase, cmdExecutor, AppConfig.Suman.Timeout, AppConfig, inputData)
+
+	return updateBootstrapRepo.UpdateBootstrapRepo()
+}
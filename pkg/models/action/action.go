@@ -0,0 +1,8 @@
+package action
+
+type InputData struct {
+	Command    string
+	State      string
+	ActionIDs  []int
+	AllSystems bool
+}
@@ -0,0 +1,6 @@
+package cleanupProfiles
+
+type InputData struct {
+	Yes    bool
+	DryRun bool
+}
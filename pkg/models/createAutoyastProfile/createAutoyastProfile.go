@@ -0,0 +1,16 @@
+package createAutoyastProfile
+
+type InputData struct {
+	Types      []string
+	Dir        string
+	OsDataFile string
+}
+
+// TemplateData - the values available in the autoyast templates
+type TemplateData struct {
+	Server        string
+	Type          string
+	OsLabel       string
+	Distribution  string
+	ParentChannel string
+}
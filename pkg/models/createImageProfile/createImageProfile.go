@@ -0,0 +1,21 @@
+package createImageProfile
+
+type InputData struct {
+	File    string
+	Replace bool
+}
+
+// ProfilesFile - the image profiles, as read from the file given to createImageProfile
+type ProfilesFile struct {
+	Profiles []ProfileSpec `yaml:"profiles"`
+}
+
+// ProfileSpec - an image profile to be created
+type ProfileSpec struct {
+	Label         string `yaml:"label"`
+	Type          string `yaml:"type"`
+	Store         string `yaml:"store"`
+	Path          string `yaml:"path"`
+	ActivationKey string `yaml:"activation_key"`
+	KiwiOptions   string `yaml:"kiwi_options"`
+}
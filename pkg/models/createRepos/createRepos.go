@@ -0,0 +1,37 @@
+package createRepos
+
+type InputData struct {
+	File   string
+	Delete bool
+}
+
+// RepoSpec - the custom channels and repositories, as read from the file given to createRepos
+type RepoSpec struct {
+	// Prefix limits deleting unlisted channels and repositories to the ones with a label starting with it
+	Prefix   string        `yaml:"prefix"`
+	Channels []ChannelSpec `yaml:"channels"`
+}
+
+// ChannelSpec - a custom software channel and its repositories
+type ChannelSpec struct {
+	Label    string     `yaml:"label"`
+	Name     string     `yaml:"name"`
+	Summary  string     `yaml:"summary"`
+	Parent   string     `yaml:"parent"`
+	Arch     string     `yaml:"arch"`
+	GpgURL   string     `yaml:"gpg_key_url"`
+	GpgID    string     `yaml:"gpg_key_id"`
+	GpgFp    string     `yaml:"gpg_key_fp"`
+	GpgCheck *bool      `yaml:"gpg_check"`
+	Repos    []RepoItem `yaml:"repos"`
+}
+
+// RepoItem - a repository, with the descriptions of the ssl certificates needed to access it
+type RepoItem struct {
+	Label      string `yaml:"label"`
+	URL        string `yaml:"url"`
+	Type       string `yaml:"type"`
+	SslCaCert  string `yaml:"ssl_ca_cert"`
+	SslCliCert string `yaml:"ssl_client_cert"`
+	SslCliKey  string `yaml:"ssl_client_key"`
+}
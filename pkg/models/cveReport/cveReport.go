@@ -0,0 +1,18 @@
+package cveReport
+
+type InputData struct {
+	Cves   []string
+	File   string
+	Format string
+	Output string
+}
+
+// Record - one line of the report: the status of a patch for a CVE on a system
+type Record struct {
+	Cve          string          `json:"cve"`
+	System       string          `json:"system"`
+	SystemID     int             `json:"system_id"`
+	PatchStatus  string          `json:"patch_status"`
+	Patch        string          `json:"patch"`
+	Environments map[string]bool `json:"environments"`
+}
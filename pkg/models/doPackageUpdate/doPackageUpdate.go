@@ -0,0 +1,8 @@
+package doPackageUpdate
+
+type InputData struct {
+	Packages  []string
+	Group     string
+	Systems   []string
+	BatchSize int
+}
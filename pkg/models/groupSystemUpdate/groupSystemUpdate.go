@@ -0,0 +1,6 @@
+package groupSystemUpdate
+
+type InputData struct {
+	Group  string
+	Reboot bool
+}
@@ -12,12 +12,19 @@ type Config struct {
 }
 
 type Suman struct {
-	Server              string `yaml:"server"`
-	User                string `yaml:"user"`
-	Password            string `yaml:"password"`
-	Timeout             int    `yaml:"timeout"`
-	SslCertificateCheck bool   `yaml:"ssl_certificate_check"`
-	RetryCount          int    `yaml:"retry_count"`
+	Server              string   `yaml:"server"`
+	User                string   `yaml:"user"`
+	Password            string   `yaml:"password"`
+	Timeout             int      `yaml:"timeout"`
+	SslCertificateCheck bool     `yaml:"ssl_certificate_check"`
+	CaBundle            string   `yaml:"ca_bundle"`
+	RequestTimeout      int      `yaml:"request_timeout"`
+	RetryCount          int      `yaml:"retry_count"`
+	SessionCache        string   `yaml:"session_cache"`
+	SessionTTL          int      `yaml:"session_ttl"`
+	CredentialSources   []string `yaml:"credential_sources"`
+	CredentialsFile     string   `yaml:"credentials_file"`
+	PasswordCommand     string   `yaml:"password_command"`
 }
 
 type SMTP struct {
@@ -59,30 +66,15 @@ type ErrorHandling struct {
 }
 
 type Maintenance struct {
-	WaitBetweenSystems  int                           `yaml:"wait_between_systems"`
-	ExcludeForPatch     []string                      `yaml:"exclude_for_patch"`
-	SpMigrationProjects map[string]SpMigrationProject `yaml:"sp_migration_project"`
-	SpMigrations        map[string]SpMigration        `yaml:"sp_migration"`
-	ExceptionSp         map[string]ExceptionSpServer  `yaml:"exception_sp"`
-}
-
-type SpMigrationProject struct {
-	Project []string
-}
-
-type SpMigration struct {
-	Project []string
-}
-
-type ExceptionSpServer struct {
-	Server []string
+	WaitBetweenSystems  int                 `yaml:"wait_between_systems"`
+	MaxParallel         int                 `yaml:"max_parallel"`
+	ExcludeForPatch     []string            `yaml:"exclude_for_patch"`
+	SpMigrationProjects map[string]string   `yaml:"sp_migration_project"`
+	SpMigrations        map[string]string   `yaml:"sp_migration"`
+	ExceptionSp         map[string][]string `yaml:"exception_sp"`
 }
 
 type BootstrapRepo struct {
-	Command string `yaml:"command"`
-	Repos   map[string]Repo
-}
-
-type Repo struct {
-	RepoName []string
+	Command string            `yaml:"command"`
+	Repos   map[string]string `yaml:"repos"`
 }
@@ -0,0 +1,22 @@
+package manageGroup
+
+type InputData struct {
+	Action      string
+	Group       string
+	Description string
+	Systems     []string
+	Regex       string
+	File        string
+	DryRun      bool
+}
+
+// GroupsFile - the desired system group membership, as read from the file given to manageGroup sync
+type GroupsFile struct {
+	Groups map[string]GroupDefinition `yaml:"groups"`
+}
+
+// GroupDefinition - the description and systems of a single system group
+type GroupDefinition struct {
+	Description string   `yaml:"description"`
+	Systems     []string `yaml:"systems"`
+}
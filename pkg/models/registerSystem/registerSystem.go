@@ -0,0 +1,22 @@
+package registerSystem
+
+type InputData struct {
+	Server        string
+	ActivationKey string
+	OsLabel       string
+	OsDataFile    string
+	Groups        []string
+	Formulas      []string
+}
+
+// OsReleaseData - the content of osreleasedata.json
+type OsReleaseData struct {
+	OsRelease []OsRelease `json:"osRelease"`
+}
+
+// OsRelease - the base channel and installation tree of an OS label
+type OsRelease struct {
+	Label         string `json:"label"`
+	ParentChannel string `json:"parent_channel"`
+	TreePath      string `json:"tree_path"`
+}
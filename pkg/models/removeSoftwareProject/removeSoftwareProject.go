@@ -0,0 +1,5 @@
+package removeSoftwareProject
+
+type InputData struct {
+	Project string
+}
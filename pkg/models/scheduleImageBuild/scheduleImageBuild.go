@@ -0,0 +1,8 @@
+package scheduleImageBuild
+
+type InputData struct {
+	Profile   string
+	BuildHost string
+	Version   string
+	Timeout   int
+}
@@ -0,0 +1,7 @@
+package spMigrate
+
+type InputData struct {
+	Server string
+	Group  string
+	DryRun bool
+}
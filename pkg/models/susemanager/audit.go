@@ -0,0 +1,10 @@
+// Package sumamodels - structs needed for SUSE Manager API Calls
+package sumamodels
+
+// AuditSystem - api call info
+type AuditSystem struct {
+	SystemID         int      `json:"system_id"`
+	PatchStatus      string   `json:"patch_status"`
+	ChannelLabels    []string `json:"channel_labels"`
+	ErrataAdvisories []string `json:"errata_advisories"`
+}
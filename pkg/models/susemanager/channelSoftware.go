@@ -5,7 +5,7 @@ package sumamodels
 type ChannelSoftwareContentSource struct {
 	ID        int    `json:"id"`
 	Label     string `json:"label"`
-	SourceURL string `json:"source_url"`
+	SourceURL string `json:"sourceUrl"`
 	Type      string `json:"type"`
 }
 
@@ -32,7 +32,7 @@ type ChannelSoftwareListChildren struct {
 	EndOfLife          string                         `json:"end_of_life"`
 	ParentChannelLabel string                         `json:"parent_channel_label"`
 	CloneOriginal      string                         `json:"clone_original"`
-	ContentSource      []ChannelSoftwareContentSource `json:"content_source,omitempty"`
+	ContentSource      []ChannelSoftwareContentSource `json:"contentSources,omitempty"`
 }
 
 type ChannelSoftwareCreateRepo struct {
@@ -47,3 +47,9 @@ type ChannelSoftwareCreateRepo struct {
 		SslCertDesc string `json:"sslCertDesc,omitempty"`
 	} `json:"sslContentSources,omitempty"`
 }
+
+// ChannelSoftwareSubscribedSystem - api call info
+type ChannelSoftwareSubscribedSystem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -69,3 +69,18 @@ type ContentManagementEnvironmentList struct {
 	PreviousEnvironmentLabel string     `json:"previousEnvironmentLabel"`
 	NextEnvironmentLabel     string     `json:"nextEnvironmentLabel"`
 }
+
+// ContentManagementProjectFilter ContentManagement listProjectFilters output
+type ContentManagementProjectFilter struct {
+	ContentProjectLabel string                               `json:"contentProjectLabel"`
+	State               string                               `json:"state"`
+	Filter              ContentManagementProjectFilterDetail `json:"filter"`
+}
+
+// ContentManagementProjectFilterDetail ContentManagement filter attached to a project
+type ContentManagementProjectFilterDetail struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Rule       string `json:"rule"`
+	EntityType string `json:"entityType"`
+}
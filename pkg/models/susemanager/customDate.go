@@ -19,6 +19,9 @@ type CustomDate time.Time
 // return: error
 func (j *CustomDate) UnmarshalJSON(b []byte) error {
 	s := strings.Trim(string(b), "\"")
+	if s == "null" || s == "" {
+		return nil
+	}
 	timeCorrect := false
 	t, err := time.Parse("2006-01-02T15:04:05-0700", s)
 	if err == nil {
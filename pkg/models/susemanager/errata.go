@@ -0,0 +1,20 @@
+// Package sumamodels - structs needed for SUSE Manager API Calls
+package sumamodels
+
+// Errata - api call info
+type Errata struct {
+	ID               int    `json:"id"`
+	Date             string `json:"date"`
+	UpdateDate       string `json:"update_date"`
+	AdvisoryName     string `json:"advisory_name"`
+	AdvisoryType     string `json:"advisory_type"`
+	AdvisorySynopsis string `json:"advisory_synopsis"`
+}
+
+// ErrataChannel - api call info
+type ErrataChannel struct {
+	ChannelID          int    `json:"channel_id"`
+	Label              string `json:"label"`
+	Name               string `json:"name"`
+	ParentChannelLabel string `json:"parent_channel_label"`
+}
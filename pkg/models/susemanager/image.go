@@ -0,0 +1,35 @@
+// Package sumamodels - structs needed for SUSE Manager API Calls
+package sumamodels
+
+// ImageProfile - an image profile, as returned by image/profile/getDetails
+type ImageProfile struct {
+	Label         string `json:"label"`
+	ImageType     string `json:"imageType"`
+	ImageStore    string `json:"imageStore"`
+	ActivationKey string `json:"activationKey"`
+	Path          string `json:"path"`
+	KiwiOptions   string `json:"kiwiOptions"`
+}
+
+// ImageStore - an image store, as returned by image/store/listImageStores
+type ImageStore struct {
+	Label     string `json:"label"`
+	URI       string `json:"uri"`
+	StoreType string `json:"storetype"`
+}
+
+// ImageOverview - a built image, as returned by image/listImages
+type ImageOverview struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Version       string `json:"version"`
+	Revision      int    `json:"revision"`
+	Arch          string `json:"arch"`
+	External      bool   `json:"external"`
+	StoreLabel    string `json:"storeLabel"`
+	ProfileLabel  string `json:"profileLabel"`
+	BuildStatus   string `json:"buildStatus"`
+	InspectStatus string `json:"inspectStatus"`
+	BuildServerID int    `json:"buildServerId"`
+}
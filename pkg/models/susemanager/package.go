@@ -21,3 +21,16 @@ type InstalledPackage struct {
 	Arch      string `json:"arch"`
 	Retracted bool   `json:"retracted"`
 }
+
+// UpgradablePackage - api call info
+type UpgradablePackage struct {
+	Name        string `json:"name"`
+	Arch        string `json:"arch"`
+	FromVersion string `json:"from_version"`
+	FromRelease string `json:"from_release"`
+	FromEpoch   string `json:"from_epoch"`
+	ToVersion   string `json:"to_version"`
+	ToRelease   string `json:"to_release"`
+	ToEpoch     string `json:"to_epoch"`
+	ToPackageID int    `json:"to_package_id"`
+}
@@ -0,0 +1,24 @@
+// Package sumamodels - structs needed for SUSE Manager API Calls
+package sumamodels
+
+// ScheduleAction - a scheduled action, as returned by the schedule/list*Actions calls
+type ScheduleAction struct {
+	ID                int        `json:"id"`
+	Name              string     `json:"name"`
+	Type              string     `json:"type"`
+	Scheduler         string     `json:"scheduler"`
+	Earliest          CustomDate `json:"earliest"`
+	Prerequisite      int        `json:"prerequisite"`
+	CompletedSystems  int        `json:"completedSystems"`
+	FailedSystems     int        `json:"failedSystems"`
+	InProgressSystems int        `json:"inProgressSystems"`
+}
+
+// ScheduleActionSystem - a system of a scheduled action, as returned by the schedule/list*Systems calls
+type ScheduleActionSystem struct {
+	ServerID    int        `json:"server_id"`
+	ServerName  string     `json:"server_name"`
+	BaseChannel string     `json:"base_channel"`
+	Timestamp   CustomDate `json:"timestamp"`
+	Message     string     `json:"message"`
+}
@@ -18,6 +18,13 @@ type ActiveSystem struct {
 	LastBoot   CustomDate `json:"last_boot"`
 }
 
+// SystemConnectionPath - api call info, one proxy between a system and the server
+type SystemConnectionPath struct {
+	Position int    `json:"position"`
+	ID       int    `json:"id"`
+	Hostname string `json:"hostname"`
+}
+
 // SubscribedBaseChannel - api call info
 type SubscribedBaseChannel struct {
 	Summary            string        `json:"summary"`
@@ -42,3 +49,26 @@ type SubscribedBaseChannel struct {
 	CloneOriginal      string        `json:"clone_original"`
 	LastModified       string        `json:"last_modified"`
 }
+
+// MigrationTarget - api call info
+type MigrationTarget struct {
+	Ident    string `json:"ident"`
+	Friendly string `json:"friendly"`
+}
+
+// SystemEvent - api call info
+type SystemEvent struct {
+	ID             int               `json:"id"`
+	HistoryType    string            `json:"history_type"`
+	Status         string            `json:"status"`
+	Summary        string            `json:"summary"`
+	ResultMsg      string            `json:"result_msg"`
+	ResultCode     int               `json:"result_code"`
+	AdditionalInfo []SystemEventInfo `json:"additional_info"`
+}
+
+// SystemEventInfo - api call info
+type SystemEventInfo struct {
+	Detail string `json:"detail"`
+	Result string `json:"result"`
+}
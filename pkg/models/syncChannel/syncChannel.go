@@ -0,0 +1,8 @@
+package syncChannel
+
+type InputData struct {
+	Channel      string
+	WithChildren bool
+	Wait         bool
+	Timeout      int
+}
@@ -0,0 +1,7 @@
+package syncEnvironment
+
+type InputData struct {
+	Project string
+	Until   string
+	Soak    int
+}
@@ -0,0 +1,8 @@
+package syncMoveServer
+
+type InputData struct {
+	Secondary string
+	Primary   string
+	CaCert    string
+	Delete    bool
+}
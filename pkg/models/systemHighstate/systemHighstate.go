@@ -0,0 +1,17 @@
+package systemHighstate
+
+type InputData struct {
+	Server string
+	Group  string
+	Test   bool
+}
+
+// StateResult - the result of a single salt state, as returned in the result of the highstate action
+type StateResult struct {
+	ID      string                 `yaml:"__id__"`
+	Name    string                 `yaml:"name"`
+	Result  *bool                  `yaml:"result"`
+	Comment interface{}            `yaml:"comment"`
+	Changes map[string]interface{} `yaml:"changes"`
+	RunNum  int                    `yaml:"__run_num__"`
+}
@@ -0,0 +1,9 @@
+package systemRereg
+
+type InputData struct {
+	Server    string
+	Proxy     string
+	Target    string
+	Bootstrap string
+	BatchSize int
+}
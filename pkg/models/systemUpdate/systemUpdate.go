@@ -0,0 +1,6 @@
+package systemUpdate
+
+type InputData struct {
+	Server string
+	Reboot bool
+}
@@ -0,0 +1,5 @@
+package updateBootstrapRepo
+
+type InputData struct {
+	Repo string
+}
@@ -0,0 +1,265 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// registerChannel - channel and channel.software api calls
+func (s *Server) registerChannel() {
+	s.handlers["channel/listSoftwareChannels"] = s.channelListSoftwareChannels
+	s.handlers["channel/software/isExisting"] = s.channelIsExisting
+	s.handlers["channel/software/listChildren"] = s.channelListChildren
+	s.handlers["channel/software/getDetails"] = s.channelGetDetails
+	s.handlers["channel/software/create"] = s.channelCreate
+	s.handlers["channel/software/delete"] = s.channelDelete
+	s.handlers["channel/software/setDetails"] = s.channelSetDetails
+	s.handlers["channel/software/listSubscribedSystems"] = s.channelListSubscribedSystems
+	s.handlers["channel/software/syncRepo"] = s.channelSyncRepo
+	s.handlers["channel/software/createRepo"] = s.channelCreateRepo
+	s.handlers["channel/software/removeRepo"] = s.channelRemoveRepo
+	s.handlers["channel/software/updateRepoUrl"] = s.channelUpdateRepoURL
+	s.handlers["channel/software/listUserRepos"] = s.channelListUserRepos
+	s.handlers["channel/software/associateRepo"] = s.channelAssociateRepo
+	s.handlers["channel/software/disassociateRepo"] = s.channelDisassociateRepo
+}
+
+// AddChannel - add a software channel, an empty parent makes it a base channel
+//
+// param: label
+// param: parent
+// return: channel id
+func (s *Server) AddChannel(label string, parent string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel := &sumamodels.ChannelSoftwareListChildren{ID: s.newID(), Label: label, Name: label, Summary: label, ArchLabel: "channel-x86_64", ParentChannelLabel: parent}
+	s.channels[label] = channel
+	return channel.ID
+}
+
+// Channel - lookup a software channel
+//
+// param: label
+// return: channel, found
+func (s *Server) Channel(label string) (sumamodels.ChannelSoftwareListChildren, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	channel, ok := s.channels[label]
+	if !ok {
+		return sumamodels.ChannelSoftwareListChildren{}, false
+	}
+	return *channel, true
+}
+
+func (s *Server) channelListSoftwareChannels(_ map[string]any) (any, error) {
+	result := []sumamodels.ChannelListSoftwareChannels{}
+	for _, channel := range s.sortedChannels() {
+		result = append(result, sumamodels.ChannelListSoftwareChannels{Label: channel.Label, Name: channel.Name, ParentLabel: channel.ParentChannelLabel, Arch: channel.ArchLabel, EndOfLife: channel.EndOfLife})
+	}
+	return result, nil
+}
+
+func (s *Server) channelIsExisting(params map[string]any) (any, error) {
+	_, ok := s.channels[str(params, "channelLabel")]
+	return ok, nil
+}
+
+func (s *Server) channelListChildren(params map[string]any) (any, error) {
+	parent, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.ChannelSoftwareListChildren{}
+	for _, channel := range s.sortedChannels() {
+		if channel.ParentChannelLabel == parent.Label {
+			result = append(result, *channel)
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) channelGetDetails(params map[string]any) (any, error) {
+	if _, ok := params["channelId"]; ok {
+		id := num(params, "channelId")
+		for _, channel := range s.channels {
+			if channel.ID == id {
+				return *channel, nil
+			}
+		}
+		return nil, fmt.Errorf("no such channel id %v", id)
+	}
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	return *channel, nil
+}
+
+func (s *Server) channelCreate(params map[string]any) (any, error) {
+	label := str(params, "label")
+	if _, ok := s.channels[label]; ok {
+		return nil, fmt.Errorf("channel %v already exists", label)
+	}
+	parent := str(params, "parentLabel")
+	if _, ok := s.channels[parent]; len(parent) > 0 && !ok {
+		return nil, fmt.Errorf("parent channel %v not found", parent)
+	}
+	s.channels[label] = &sumamodels.ChannelSoftwareListChildren{ID: s.newID(), Label: label, Name: str(params, "name"), Summary: str(params, "summary"), ArchLabel: str(params, "archLabel"), ParentChannelLabel: parent}
+	return 1, nil
+}
+
+func (s *Server) channelDelete(params map[string]any) (any, error) {
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range s.channels {
+		if child.ParentChannelLabel == channel.Label {
+			return nil, fmt.Errorf("channel %v has child channels", channel.Label)
+		}
+	}
+	delete(s.channels, channel.Label)
+	return 1, nil
+}
+
+func (s *Server) channelSetDetails(params map[string]any) (any, error) {
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	details, _ := params["details"].(map[string]any)
+	for key := range details {
+		switch key {
+		case "name":
+			channel.Name = str(details, key)
+		case "summary":
+			channel.Summary = str(details, key)
+		case "description":
+			channel.Description = str(details, key)
+		case "gpg_key_url":
+			channel.GpgKeyURL = str(details, key)
+		case "gpg_key_id":
+			channel.GpgKeyID = str(details, key)
+		case "gpg_key_fp":
+			channel.GpgKeyfp = str(details, key)
+		case "gpg_check":
+			channel.GpgCheck = flag(details, key)
+		}
+	}
+	return 1, nil
+}
+
+func (s *Server) channelListSubscribedSystems(params map[string]any) (any, error) {
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.ChannelSoftwareSubscribedSystem{}
+	for _, system := range s.sortedSystems() {
+		if system.BaseChannel == channel.Label {
+			result = append(result, sumamodels.ChannelSoftwareSubscribedSystem{ID: system.ID, Name: system.Name})
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) channelSyncRepo(params map[string]any) (any, error) {
+	if _, err := s.channel(str(params, "channelLabel")); err != nil {
+		return nil, err
+	}
+	return 1, nil
+}
+
+func (s *Server) channelCreateRepo(params map[string]any) (any, error) {
+	label := str(params, "label")
+	for _, repo := range s.userRepos {
+		if repo.Label == label {
+			return nil, fmt.Errorf("repository %v already exists", label)
+		}
+	}
+	repo := sumamodels.ChannelSoftwareContentSource{ID: s.newID(), Label: label, SourceURL: str(params, "url"), Type: str(params, "type")}
+	s.userRepos = append(s.userRepos, repo)
+	return sumamodels.ChannelSoftwareCreateRepo{ID: repo.ID, Label: repo.Label, SourceURL: repo.SourceURL, Type: repo.Type}, nil
+}
+
+func (s *Server) channelRemoveRepo(params map[string]any) (any, error) {
+	label := str(params, "label")
+	for i, repo := range s.userRepos {
+		if repo.Label == label {
+			s.userRepos = append(s.userRepos[:i], s.userRepos[i+1:]...)
+			return 1, nil
+		}
+	}
+	return nil, fmt.Errorf("repository %v not found", label)
+}
+
+func (s *Server) channelUpdateRepoURL(params map[string]any) (any, error) {
+	label := str(params, "label")
+	for i := range s.userRepos {
+		if s.userRepos[i].Label == label {
+			s.userRepos[i].SourceURL = str(params, "url")
+			return 1, nil
+		}
+	}
+	return nil, fmt.Errorf("repository %v not found", label)
+}
+
+func (s *Server) channelListUserRepos(_ map[string]any) (any, error) {
+	result := []sumamodels.ChannelSoftwareCreateRepo{}
+	for _, repo := range s.userRepos {
+		result = append(result, sumamodels.ChannelSoftwareCreateRepo{ID: repo.ID, Label: repo.Label, SourceURL: repo.SourceURL, Type: repo.Type})
+	}
+	return result, nil
+}
+
+func (s *Server) channelAssociateRepo(params map[string]any) (any, error) {
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	label := str(params, "repoLabel")
+	for _, repo := range s.userRepos {
+		if repo.Label == label {
+			channel.ContentSource = append(channel.ContentSource, repo)
+			return *channel, nil
+		}
+	}
+	return nil, fmt.Errorf("repository %v not found", label)
+}
+
+func (s *Server) channelDisassociateRepo(params map[string]any) (any, error) {
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	label := str(params, "repoLabel")
+	for i, repo := range channel.ContentSource {
+		if repo.Label == label {
+			channel.ContentSource = append(channel.ContentSource[:i], channel.ContentSource[i+1:]...)
+			return *channel, nil
+		}
+	}
+	return nil, fmt.Errorf("repository %v not associated with channel %v", label, channel.Label)
+}
+
+// channel - lookup a channel
+func (s *Server) channel(label string) (*sumamodels.ChannelSoftwareListChildren, error) {
+	channel, ok := s.channels[label]
+	if !ok {
+		return nil, fmt.Errorf("no such channel %v", label)
+	}
+	return channel, nil
+}
+
+// sortedChannels - all channels ordered by label
+func (s *Server) sortedChannels() []*sumamodels.ChannelSoftwareListChildren {
+	var result []*sumamodels.ChannelSoftwareListChildren
+	for _, channel := range s.channels {
+		result = append(result, channel)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Label < result[j].Label })
+	return result
+}
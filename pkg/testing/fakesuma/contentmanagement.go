@@ -0,0 +1,415 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// project - content lifecycle project with its environments, sources and filters
+type project struct {
+	info    sumamodels.ContentManagementListProjects
+	envs    []*sumamodels.ContentManagementEnvironmentList
+	sources []sumamodels.ContentManagementSource
+	filters []int
+}
+
+// registerContentManagement - contentmanagement api calls
+func (s *Server) registerContentManagement() {
+	s.handlers["contentmanagement/listProjects"] = s.cmListProjects
+	s.handlers["contentmanagement/lookupProject"] = s.cmLookupProject
+	s.handlers["contentmanagement/createProject"] = s.cmCreateProject
+	s.handlers["contentmanagement/removeProject"] = s.cmRemoveProject
+	s.handlers["contentmanagement/listProjectEnvironments"] = s.cmListProjectEnvironments
+	s.handlers["contentmanagement/lookupEnvironment"] = s.cmLookupEnvironment
+	s.handlers["contentmanagement/createEnvironment"] = s.cmCreateEnvironment
+	s.handlers["contentmanagement/removeEnvironment"] = s.cmRemoveEnvironment
+	s.handlers["contentmanagement/listProjectSources"] = s.cmListProjectSources
+	s.handlers["contentmanagement/attachSource"] = s.cmAttachSource
+	s.handlers["contentmanagement/detachSource"] = s.cmDetachSource
+	s.handlers["contentmanagement/buildProject"] = s.cmBuildProject
+	s.handlers["contentmanagement/promoteProject"] = s.cmPromoteProject
+	s.handlers["contentmanagement/listFilters"] = s.cmListFilters
+	s.handlers["contentmanagement/createFilter"] = s.cmCreateFilter
+	s.handlers["contentmanagement/listProjectFilters"] = s.cmListProjectFilters
+	s.handlers["contentmanagement/attachFilter"] = s.cmAttachFilter
+	s.handlers["contentmanagement/detachFilter"] = s.cmDetachFilter
+}
+
+// AddProject - add a content lifecycle project with the given environments, first to last. All environments have
+// never been built.
+//
+// param: label
+// param: envs
+func (s *Server) AddProject(label string, envs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[label] = &project{info: sumamodels.ContentManagementListProjects{ID: s.newID(), Label: label, Name: label, OrgID: 1}}
+	predecessor := ""
+	for _, env := range envs {
+		_, _ = s.createEnvironment(label, predecessor, env, env, "")
+		predecessor = env
+	}
+}
+
+// SetEnvironmentStatus - set the build status of an environment, e.g. built, building or unknown
+//
+// param: projectLabel
+// param: envLabel
+// param: status
+func (s *Server) SetEnvironmentStatus(projectLabel string, envLabel string, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if env := s.environment(projectLabel, envLabel); env != nil {
+		env.Status = status
+	}
+}
+
+// Project - lookup a project
+//
+// param: label
+// return: project, found
+func (s *Server) Project(label string) (sumamodels.ContentManagementListProjects, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[label]
+	if !ok {
+		return sumamodels.ContentManagementListProjects{}, false
+	}
+	return p.info, true
+}
+
+// Environments - environments of a project, first to last
+//
+// param: projectLabel
+// return: []ContentManagementEnvironmentList
+func (s *Server) Environments(projectLabel string) []sumamodels.ContentManagementEnvironmentList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []sumamodels.ContentManagementEnvironmentList
+	if p, ok := s.projects[projectLabel]; ok {
+		for _, env := range p.envs {
+			result = append(result, *env)
+		}
+	}
+	return result
+}
+
+// Sources - labels of the software channels attached to a project
+//
+// param: projectLabel
+// return: []string
+func (s *Server) Sources(projectLabel string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []string
+	if p, ok := s.projects[projectLabel]; ok {
+		for _, source := range p.sources {
+			result = append(result, source.ChannelLabel)
+		}
+	}
+	return result
+}
+
+func (s *Server) cmListProjects(_ map[string]any) (any, error) {
+	result := []sumamodels.ContentManagementListProjects{}
+	for _, p := range s.projects {
+		result = append(result, p.info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Label < result[j].Label })
+	return result, nil
+}
+
+func (s *Server) cmLookupProject(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	return p.info, nil
+}
+
+func (s *Server) cmCreateProject(params map[string]any) (any, error) {
+	label := str(params, "projectLabel")
+	if _, ok := s.projects[label]; ok {
+		return nil, fmt.Errorf("content project %v already exists", label)
+	}
+	p := &project{info: sumamodels.ContentManagementListProjects{ID: s.newID(), Label: label, Name: str(params, "name"), Description: str(params, "description"), OrgID: 1}}
+	s.projects[label] = p
+	return p.info, nil
+}
+
+func (s *Server) cmRemoveProject(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	delete(s.projects, p.info.Label)
+	return 1, nil
+}
+
+func (s *Server) cmListProjectEnvironments(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.ContentManagementEnvironmentList{}
+	for _, env := range p.envs {
+		result = append(result, *env)
+	}
+	return result, nil
+}
+
+func (s *Server) cmLookupEnvironment(params map[string]any) (any, error) {
+	envLabel := str(params, "envLabel")
+	if _, err := s.project(str(params, "projectLabel")); err != nil {
+		return nil, err
+	}
+	env := s.environment(str(params, "projectLabel"), envLabel)
+	if env == nil {
+		return nil, fmt.Errorf("%v", envLabel)
+	}
+	return *env, nil
+}
+
+func (s *Server) cmCreateEnvironment(params map[string]any) (any, error) {
+	env, err := s.createEnvironment(str(params, "projectLabel"), str(params, "predecessorLabel"), str(params, "envLabel"), str(params, "name"), str(params, "description"))
+	if err != nil {
+		return nil, err
+	}
+	return sumamodels.ContentManagementEnvironmentCreate{Name: env.Name, ContentProjectLabel: env.ContentProjectLabel, ID: env.ID, Label: env.Label, Status: env.Status}, nil
+}
+
+func (s *Server) cmRemoveEnvironment(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	envLabel := str(params, "envLabel")
+	for i, env := range p.envs {
+		if env.Label == envLabel {
+			p.envs = append(p.envs[:i], p.envs[i+1:]...)
+			linkEnvironments(p)
+			return 1, nil
+		}
+	}
+	return nil, fmt.Errorf("%v", envLabel)
+}
+
+func (s *Server) cmListProjectSources(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]sumamodels.ContentManagementSource{}, p.sources...), nil
+}
+
+func (s *Server) cmAttachSource(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	label := str(params, "sourceLabel")
+	if _, ok := s.channels[label]; !ok {
+		return nil, fmt.Errorf("channel %v not found", label)
+	}
+	for _, source := range p.sources {
+		if source.ChannelLabel == label {
+			return source, nil
+		}
+	}
+	source := sumamodels.ContentManagementSource{ContentProjectLabel: p.info.Label, Type: str(params, "sourceType"), State: "ATTACHED", ChannelLabel: label}
+	p.sources = append(p.sources, source)
+	return source, nil
+}
+
+func (s *Server) cmDetachSource(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	label := str(params, "sourceLabel")
+	for i, source := range p.sources {
+		if source.ChannelLabel == label {
+			p.sources = append(p.sources[:i], p.sources[i+1:]...)
+			return 1, nil
+		}
+	}
+	return nil, fmt.Errorf("source %v not attached to project %v", label, p.info.Label)
+}
+
+func (s *Server) cmBuildProject(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	if len(p.envs) == 0 {
+		return nil, fmt.Errorf("project %v has no environments", p.info.Label)
+	}
+	first := p.envs[0]
+	first.Version++
+	first.Status = "built"
+	first.LastBuildDate = now()
+	p.info.LastBuildDate = first.LastBuildDate
+	return 1, nil
+}
+
+func (s *Server) cmPromoteProject(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	envLabel := str(params, "envLabel")
+	for i, env := range p.envs {
+		if env.Label != envLabel {
+			continue
+		}
+		if i == len(p.envs)-1 {
+			return nil, fmt.Errorf("environment %v has no successor", envLabel)
+		}
+		next := p.envs[i+1]
+		next.Version = env.Version
+		next.Status = "built"
+		next.LastBuildDate = now()
+		return 1, nil
+	}
+	return nil, fmt.Errorf("%v", envLabel)
+}
+
+func (s *Server) cmListFilters(_ map[string]any) (any, error) {
+	result := []sumamodels.ContentManagementFilter{}
+	for _, filter := range s.filters {
+		result = append(result, filter)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (s *Server) cmCreateFilter(params map[string]any) (any, error) {
+	criteria, _ := params["criteria"].(map[string]any)
+	value, _ := time.Parse(time.RFC3339, str(criteria, "value"))
+	filter := sumamodels.ContentManagementFilter{
+		ID:         s.newID(),
+		Name:       str(params, "name"),
+		Rule:       str(params, "rule"),
+		EntityType: str(params, "entityType"),
+		OrgID:      1,
+		Criteria:   sumamodels.ContentManagementFilterCriteria{Field: str(criteria, "field"), Matcher: str(criteria, "matcher"), Value: value},
+	}
+	s.filters[filter.ID] = filter
+	return filter, nil
+}
+
+func (s *Server) cmListProjectFilters(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.ContentManagementProjectFilter{}
+	for _, id := range p.filters {
+		filter := s.filters[id]
+		result = append(result, sumamodels.ContentManagementProjectFilter{
+			ContentProjectLabel: p.info.Label,
+			State:               "ATTACHED",
+			Filter:              sumamodels.ContentManagementProjectFilterDetail{ID: id, Name: filter.Name, Rule: filter.Rule, EntityType: filter.EntityType},
+		})
+	}
+	return result, nil
+}
+
+func (s *Server) cmAttachFilter(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	filter, ok := s.filters[num(params, "filterId")]
+	if !ok {
+		return nil, fmt.Errorf("filter %v not found", num(params, "filterId"))
+	}
+	p.filters = append(p.filters, filter.ID)
+	return filter, nil
+}
+
+func (s *Server) cmDetachFilter(params map[string]any) (any, error) {
+	p, err := s.project(str(params, "projectLabel"))
+	if err != nil {
+		return nil, err
+	}
+	id := num(params, "filterId")
+	for i := range p.filters {
+		if p.filters[i] == id {
+			p.filters = append(p.filters[:i], p.filters[i+1:]...)
+			return 1, nil
+		}
+	}
+	return nil, fmt.Errorf("filter %v not attached to project %v", id, p.info.Label)
+}
+
+// project - lookup a project, the error message is the label like the real api
+func (s *Server) project(label string) (*project, error) {
+	p, ok := s.projects[label]
+	if !ok {
+		return nil, fmt.Errorf("%v", label)
+	}
+	return p, nil
+}
+
+// environment - lookup an environment of a project, nil if not found
+func (s *Server) environment(projectLabel string, envLabel string) *sumamodels.ContentManagementEnvironmentList {
+	p, ok := s.projects[projectLabel]
+	if !ok {
+		return nil
+	}
+	for _, env := range p.envs {
+		if env.Label == envLabel {
+			return env
+		}
+	}
+	return nil
+}
+
+// createEnvironment - insert a new environment after its predecessor, an empty predecessor makes it the first one
+func (s *Server) createEnvironment(projectLabel string, predecessor string, envLabel string, name string, description string) (*sumamodels.ContentManagementEnvironmentList, error) {
+	p, err := s.project(projectLabel)
+	if err != nil {
+		return nil, err
+	}
+	if s.environment(projectLabel, envLabel) != nil {
+		return nil, fmt.Errorf("environment %v already exists in project %v", envLabel, projectLabel)
+	}
+	position := 0
+	if len(predecessor) > 0 {
+		position = -1
+		for i, env := range p.envs {
+			if env.Label == predecessor {
+				position = i + 1
+			}
+		}
+		if position < 0 {
+			return nil, fmt.Errorf("predecessor environment %v not found in project %v", predecessor, projectLabel)
+		}
+	}
+	env := &sumamodels.ContentManagementEnvironmentList{ID: s.newID(), Label: envLabel, Name: name, Description: description, ContentProjectLabel: projectLabel, Status: "unknown"}
+	p.envs = append(p.envs[:position], append([]*sumamodels.ContentManagementEnvironmentList{env}, p.envs[position:]...)...)
+	linkEnvironments(p)
+	return env, nil
+}
+
+// linkEnvironments - recalculate the previous and next environment labels
+func linkEnvironments(p *project) {
+	for i, env := range p.envs {
+		env.PreviousEnvironmentLabel = ""
+		env.NextEnvironmentLabel = ""
+		if i > 0 {
+			env.PreviousEnvironmentLabel = p.envs[i-1].Label
+		}
+		if i < len(p.envs)-1 {
+			env.NextEnvironmentLabel = p.envs[i+1].Label
+		}
+	}
+	if len(p.envs) > 0 {
+		p.info.FirstEnvironment = p.envs[0].Label
+	}
+}
@@ -0,0 +1,82 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+)
+
+// registerFormula - formula api calls
+func (s *Server) registerFormula() {
+	s.handlers["formula/getSystemFormulaData"] = s.formulaGetData("system", "systemId")
+	s.handlers["formula/getGroupFormulaData"] = s.formulaGetData("group", "groupId")
+	s.handlers["formula/setSystemFormulaData"] = s.formulaSetData("system", "systemId")
+	s.handlers["formula/setGroupFormulaData"] = s.formulaSetData("group", "groupId")
+	s.handlers["formula/getFormulasByServerId"] = s.formulaGetFormulas("system", "sid")
+	s.handlers["formula/getFormulasByGroupId"] = s.formulaGetFormulas("group", "systemGroupId")
+	s.handlers["formula/setFormulasOfServer"] = s.formulaSetFormulas("system", "sid")
+	s.handlers["formula/setFormulasOfGroup"] = s.formulaSetFormulas("group", "systemGroupId")
+}
+
+// SetFormulaData - set the formula data of a system or group, kind is system or group
+//
+// param: kind
+// param: id
+// param: formulaName
+// param: data
+func (s *Server) SetFormulaData(kind string, id int, formulaName string, data map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.formulas[formulaKey(kind, id, formulaName)] = data
+}
+
+// FormulaData - formula data of a system or group, kind is system or group
+//
+// param: kind
+// param: id
+// param: formulaName
+// return: data
+func (s *Server) FormulaData(kind string, id int, formulaName string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.formulas[formulaKey(kind, id, formulaName)]
+}
+
+func (s *Server) formulaGetData(kind string, idParam string) handler {
+	return func(params map[string]any) (any, error) {
+		data, ok := s.formulas[formulaKey(kind, num(params, idParam), str(params, "formulaName"))]
+		if !ok {
+			return map[string]any{}, nil
+		}
+		return data, nil
+	}
+}
+
+func (s *Server) formulaSetData(kind string, idParam string) handler {
+	return func(params map[string]any) (any, error) {
+		s.formulas[formulaKey(kind, num(params, idParam), str(params, "formulaName"))] = params["content"]
+		return 1, nil
+	}
+}
+
+func (s *Server) formulaGetFormulas(kind string, idParam string) handler {
+	return func(params map[string]any) (any, error) {
+		return append([]string{}, s.assigned[fmt.Sprintf("%v/%v", kind, num(params, idParam))]...), nil
+	}
+}
+
+func (s *Server) formulaSetFormulas(kind string, idParam string) handler {
+	return func(params map[string]any) (any, error) {
+		var names []string
+		list, _ := params["formulas"].([]any)
+		for _, name := range list {
+			names = append(names, fmt.Sprint(name))
+		}
+		s.assigned[fmt.Sprintf("%v/%v", kind, num(params, idParam))] = names
+		return 1, nil
+	}
+}
+
+// formulaKey - key of formula data in the formulas map
+func formulaKey(kind string, id int, formulaName string) string {
+	return fmt.Sprintf("%v/%v/%v", kind, id, formulaName)
+}
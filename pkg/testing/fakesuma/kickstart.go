@@ -0,0 +1,144 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// registerKickstart - kickstart api calls
+func (s *Server) registerKickstart() {
+	s.handlers["kickstart/tree/getDetails"] = s.kickstartTreeGetDetails
+	s.handlers["kickstart/tree/create"] = s.kickstartTreeCreate
+	s.handlers["kickstart/importRawFile"] = s.kickstartImportRawFile
+	s.handlers["kickstart/listKickstarts"] = s.kickstartListKickstarts
+	s.handlers["kickstart/deleteProfile"] = s.kickstartDeleteProfile
+	s.handlers["kickstart/renameProfile"] = s.kickstartRenameProfile
+	s.handlers["kickstart/profile/setVariables"] = s.kickstartSetVariables
+}
+
+// AddKickstartTree - add an autoinstallable distribution
+//
+// param: label
+// param: channelLabel
+// return: tree id
+func (s *Server) AddKickstartTree(label string, channelLabel string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.createTree(label, "", channelLabel, "")
+}
+
+// AddKickstartProfile - add an autoinstallation profile
+//
+// param: label
+// param: treeLabel
+func (s *Server) AddKickstartProfile(label string, treeLabel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles[label] = sumamodels.KickstartListProfiles{Label: label, Name: label, TreeLabel: treeLabel, Active: true, AdvancedMode: true}
+}
+
+// KickstartProfiles - labels of all autoinstallation profiles
+//
+// return: []string
+func (s *Server) KickstartProfiles() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []string
+	for label := range s.profiles {
+		result = append(result, label)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (s *Server) kickstartTreeGetDetails(params map[string]any) (any, error) {
+	tree, ok := s.trees[str(params, "treeLabel")]
+	if !ok {
+		return nil, fmt.Errorf("no such kickstartable tree %v", str(params, "treeLabel"))
+	}
+	return tree, nil
+}
+
+func (s *Server) kickstartTreeCreate(params map[string]any) (any, error) {
+	label := str(params, "treeLabel")
+	if _, ok := s.trees[label]; ok {
+		return nil, fmt.Errorf("kickstartable tree %v already exists", label)
+	}
+	channel, err := s.channel(str(params, "channelLabel"))
+	if err != nil {
+		return nil, err
+	}
+	s.createTree(label, str(params, "basePath"), channel.Label, str(params, "installType"))
+	return 1, nil
+}
+
+func (s *Server) kickstartImportRawFile(params map[string]any) (any, error) {
+	label := str(params, "profileLabel")
+	if _, ok := s.profiles[label]; ok {
+		return nil, fmt.Errorf("autoinstallation profile %v already exists", label)
+	}
+	treeLabel := str(params, "kickstartableTreeLabel")
+	if _, ok := s.trees[treeLabel]; !ok {
+		return nil, fmt.Errorf("no such kickstartable tree %v", treeLabel)
+	}
+	s.profiles[label] = sumamodels.KickstartListProfiles{Label: label, Name: label, TreeLabel: treeLabel, Active: true, AdvancedMode: true}
+	return 1, nil
+}
+
+func (s *Server) kickstartListKickstarts(_ map[string]any) (any, error) {
+	result := []sumamodels.KickstartListProfiles{}
+	for _, profile := range s.profiles {
+		result = append(result, profile)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Label < result[j].Label })
+	return result, nil
+}
+
+func (s *Server) kickstartDeleteProfile(params map[string]any) (any, error) {
+	label := str(params, "ksLabel")
+	if _, ok := s.profiles[label]; !ok {
+		return nil, fmt.Errorf("no such autoinstallation profile %v", label)
+	}
+	delete(s.profiles, label)
+	return 1, nil
+}
+
+func (s *Server) kickstartRenameProfile(params map[string]any) (any, error) {
+	label := str(params, "originalLabel")
+	profile, ok := s.profiles[label]
+	if !ok {
+		return nil, fmt.Errorf("no such autoinstallation profile %v", label)
+	}
+	newLabel := str(params, "newLabel")
+	if _, ok := s.profiles[newLabel]; ok {
+		return nil, fmt.Errorf("autoinstallation profile %v already exists", newLabel)
+	}
+	delete(s.profiles, label)
+	profile.Label = newLabel
+	profile.Name = newLabel
+	s.profiles[newLabel] = profile
+	return 1, nil
+}
+
+func (s *Server) kickstartSetVariables(params map[string]any) (any, error) {
+	label := str(params, "ksLabel")
+	if _, ok := s.profiles[label]; !ok {
+		return nil, fmt.Errorf("no such autoinstallation profile %v", label)
+	}
+	return 1, nil
+}
+
+// createTree - add a tree pointing to the channel, the channel id is 0 when the channel is unknown
+func (s *Server) createTree(label string, basePath string, channelLabel string, installType string) int {
+	tree := sumamodels.KickstartTreeGetDetails{ID: s.newID(), Label: label, AbsPath: basePath}
+	if channel, ok := s.channels[channelLabel]; ok {
+		tree.ChannelID = channel.ID
+	}
+	tree.InstallType.Label = installType
+	tree.InstallType.Name = installType
+	s.trees[label] = tree
+	return tree.ID
+}
@@ -0,0 +1,200 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+const (
+	// StateInProgress - action is queued or running
+	StateInProgress = "inprogress"
+	// StateCompleted - action finished successfully
+	StateCompleted = "completed"
+	// StateFailed - action failed
+	StateFailed = "failed"
+)
+
+// Action - action scheduled on one system
+type Action struct {
+	ID       int
+	Name     string
+	Type     string
+	SystemID int
+	State    string
+	Message  string
+	Archived bool
+	// Script - script of a script run action
+	Script   string
+	earliest sumamodels.CustomDate
+	complete func()
+}
+
+// registerSchedule - schedule api calls
+func (s *Server) registerSchedule() {
+	s.handlers["schedule/listAllActions"] = s.scheduleListActions(func(a *Action) bool { return !a.Archived })
+	s.handlers["schedule/listArchivedActions"] = s.scheduleListActions(func(a *Action) bool { return a.Archived })
+	s.handlers["schedule/listInProgressActions"] = s.scheduleListActions(func(a *Action) bool { return !a.Archived && a.State == StateInProgress })
+	s.handlers["schedule/listCompletedActions"] = s.scheduleListActions(func(a *Action) bool { return !a.Archived && a.State == StateCompleted })
+	s.handlers["schedule/listFailedActions"] = s.scheduleListActions(func(a *Action) bool { return !a.Archived && a.State == StateFailed })
+	s.handlers["schedule/listInProgressSystems"] = s.scheduleListSystems(StateInProgress)
+	s.handlers["schedule/listCompletedSystems"] = s.scheduleListSystems(StateCompleted)
+	s.handlers["schedule/listFailedSystems"] = s.scheduleListSystems(StateFailed)
+	s.handlers["schedule/archiveActions"] = s.scheduleArchiveActions
+	s.handlers["schedule/cancelActions"] = s.scheduleCancelActions
+	s.handlers["schedule/deleteActions"] = s.scheduleDeleteActions
+	s.handlers["schedule/failSystemAction"] = s.scheduleFailSystemAction
+	s.handlers["schedule/rescheduleActions"] = s.scheduleRescheduleActions
+}
+
+// SetActionState - move an action to the given state. Completing an action applies its effect on the system, e.g.
+// removes the applied errata.
+//
+// param: actionID
+// param: state
+// param: message
+func (s *Server) SetActionState(actionID int, state string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if action, ok := s.actions[actionID]; ok {
+		s.setState(action, state, message)
+	}
+}
+
+// Actions - all actions scheduled on a system, 0 for all systems
+//
+// param: systemID
+// return: []Action
+func (s *Server) Actions(systemID int) []Action {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []Action
+	for _, action := range s.sortedActions() {
+		if systemID == 0 || action.SystemID == systemID {
+			result = append(result, *action)
+		}
+	}
+	return result
+}
+
+// scheduleAction - create an action in the state given by ActionState
+func (s *Server) scheduleAction(systemID int, name string, actionType string, complete func()) int {
+	action := &Action{ID: s.newID(), Name: name, Type: actionType, SystemID: systemID, State: StateInProgress, earliest: now(), complete: complete}
+	s.actions[action.ID] = action
+	s.setState(action, s.ActionState, "")
+	return action.ID
+}
+
+// setState - set the state and apply the effect of a completed action once
+func (s *Server) setState(action *Action, state string, message string) {
+	action.State = state
+	action.Message = message
+	if state == StateCompleted && action.complete != nil {
+		action.complete()
+		action.complete = nil
+	}
+}
+
+func (s *Server) scheduleListActions(filter func(*Action) bool) handler {
+	return func(_ map[string]any) (any, error) {
+		result := []sumamodels.ScheduleAction{}
+		for _, action := range s.sortedActions() {
+			if filter(action) {
+				result = append(result, toScheduleAction(action))
+			}
+		}
+		return result, nil
+	}
+}
+
+func (s *Server) scheduleListSystems(state string) handler {
+	return func(params map[string]any) (any, error) {
+		action, ok := s.actions[num(params, "actionId")]
+		if !ok {
+			return nil, fmt.Errorf("no such action %v", num(params, "actionId"))
+		}
+		result := []sumamodels.ScheduleActionSystem{}
+		if action.State == state {
+			name := ""
+			baseChannel := ""
+			if system, ok := s.systems[action.SystemID]; ok {
+				name = system.Name
+				baseChannel = system.BaseChannel
+			}
+			result = append(result, sumamodels.ScheduleActionSystem{ServerID: action.SystemID, ServerName: name, BaseChannel: baseChannel, Timestamp: now(), Message: action.Message})
+		}
+		return result, nil
+	}
+}
+
+func (s *Server) scheduleArchiveActions(params map[string]any) (any, error) {
+	for _, id := range nums(params, "actionIds") {
+		if action, ok := s.actions[id]; ok {
+			action.Archived = true
+		}
+	}
+	return 1, nil
+}
+
+func (s *Server) scheduleCancelActions(params map[string]any) (any, error) {
+	for _, id := range nums(params, "actionIds") {
+		if action, ok := s.actions[id]; ok && action.State == StateInProgress {
+			delete(s.actions, id)
+		}
+	}
+	return 1, nil
+}
+
+func (s *Server) scheduleDeleteActions(params map[string]any) (any, error) {
+	for _, id := range nums(params, "actionIds") {
+		delete(s.actions, id)
+	}
+	return 1, nil
+}
+
+func (s *Server) scheduleFailSystemAction(params map[string]any) (any, error) {
+	action, ok := s.actions[num(params, "actionId")]
+	if !ok || action.SystemID != num(params, "sid") {
+		return nil, fmt.Errorf("no such action %v for system %v", num(params, "actionId"), num(params, "sid"))
+	}
+	s.setState(action, StateFailed, str(params, "message"))
+	return 1, nil
+}
+
+func (s *Server) scheduleRescheduleActions(params map[string]any) (any, error) {
+	onlyFailed := flag(params, "onlyFailed")
+	for _, id := range nums(params, "actionIds") {
+		action, ok := s.actions[id]
+		if !ok || (onlyFailed && action.State != StateFailed) {
+			continue
+		}
+		s.setState(action, s.ActionState, "")
+	}
+	return 1, nil
+}
+
+// sortedActions - all actions ordered by id
+func (s *Server) sortedActions() []*Action {
+	var result []*Action
+	for _, action := range s.actions {
+		result = append(result, action)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// toScheduleAction - api representation of an action
+func toScheduleAction(action *Action) sumamodels.ScheduleAction {
+	result := sumamodels.ScheduleAction{ID: action.ID, Name: action.Name, Type: action.Type, Scheduler: "admin", Earliest: action.earliest}
+	switch action.State {
+	case StateCompleted:
+		result.CompletedSystems = 1
+	case StateFailed:
+		result.FailedSystems = 1
+	default:
+		result.InProgressSystems = 1
+	}
+	return result
+}
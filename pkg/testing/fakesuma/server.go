@@ -0,0 +1,353 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/rest"
+)
+
+const (
+	// BasePath - api base path served by the fake, the same as used by the controllers
+	BasePath = "rhn/manager/api"
+	// SessionCookie - name of the session cookie returned by auth/login
+	SessionCookie = "pxt-session-cookie"
+	retryCount    = 3
+)
+
+// handler - implementation of one api call. The returned result is sent as "result" of a successful response, the
+// returned error as "message" of a failed one.
+type handler func(params map[string]any) (any, error)
+
+// failure - scripted failure of an api call
+type failure struct {
+	status    int
+	message   string
+	remaining int
+}
+
+// Server - fake SUSE Multi-Linux Manager server. All state is kept in memory and can be set up and inspected by the
+// exported methods. The server is safe for concurrent use.
+type Server struct {
+	srv      *httptest.Server
+	mu       sync.Mutex
+	login    string
+	password string
+	sessions map[string]bool
+	nextID   int
+	handlers map[string]handler
+	failures map[string]*failure
+	calls    map[string]int
+
+	projects  map[string]*project
+	filters   map[int]sumamodels.ContentManagementFilter
+	channels  map[string]*sumamodels.ChannelSoftwareListChildren
+	userRepos []sumamodels.ChannelSoftwareContentSource
+	systems   map[int]*System
+	groups    map[string]*group
+	actions   map[int]*Action
+	formulas  map[string]any
+	assigned  map[string][]string
+	slaves    map[int]sumamodels.Slaves
+	masters   map[int]sumamodels.SlavesIssMaster
+	trees     map[string]sumamodels.KickstartTreeGetDetails
+	profiles  map[string]sumamodels.KickstartListProfiles
+
+	// ActionState - state of newly scheduled actions: completed (default), inprogress or failed
+	ActionState string
+	// RunScript - effect of a completed script run on the system, called with the server locked
+	RunScript func(system *System, script string)
+}
+
+// New - start a fake server accepting the given credentials
+//
+// param: login
+// param: password
+// return: *Server
+func New(login string, password string) *Server {
+	s := &Server{
+		login:       login,
+		password:    password,
+		sessions:    make(map[string]bool),
+		nextID:      1000,
+		handlers:    make(map[string]handler),
+		failures:    make(map[string]*failure),
+		calls:       make(map[string]int),
+		projects:    make(map[string]*project),
+		filters:     make(map[int]sumamodels.ContentManagementFilter),
+		channels:    make(map[string]*sumamodels.ChannelSoftwareListChildren),
+		systems:     make(map[int]*System),
+		groups:      make(map[string]*group),
+		actions:     make(map[int]*Action),
+		formulas:    make(map[string]any),
+		assigned:    make(map[string][]string),
+		slaves:      make(map[int]sumamodels.Slaves),
+		masters:     make(map[int]sumamodels.SlavesIssMaster),
+		trees:       make(map[string]sumamodels.KickstartTreeGetDetails),
+		profiles:    make(map[string]sumamodels.KickstartListProfiles),
+		ActionState: StateCompleted,
+	}
+	s.registerContentManagement()
+	s.registerChannel()
+	s.registerSystem()
+	s.registerSystemGroup()
+	s.registerSchedule()
+	s.registerSync()
+	s.registerFormula()
+	s.registerKickstart()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close - shut the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Host - host:port to be used as suman server
+//
+// return: string
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "http://")
+}
+
+// Config - SUSE Manager configuration pointing to the fake server
+//
+// return: *SumanConfig
+func (s *Server) Config() *_sumanUseCase.SumanConfig {
+	return &_sumanUseCase.SumanConfig{
+		Host:     s.Host(),
+		Login:    s.login,
+		Password: s.password,
+	}
+}
+
+// Proxy - api proxy talking plain http to the fake server
+//
+// return: IProxy
+func (s *Server) Proxy() _sumanUseCase.IProxy {
+	return s.ProxyContext(context.Background())
+}
+
+// ProxyContext - api proxy talking plain http to the fake server, its calls and waits are cancelled with ctx
+//
+// param: ctx
+// return: IProxy
+func (s *Server) ProxyContext(ctx context.Context) _sumanUseCase.IProxy {
+	client, _ := rest.NewClient(rest.ClientConfig{Insecure: true, RetryCount: retryCount})
+	suseAPI := _sumanUseCase.NewSuseManagerAPIClient(ctx, BasePath, client, true)
+	return _sumanUseCase.NewProxy(s.Config(), suseAPI, retryCount)
+}
+
+// Fail - let the next times calls of path fail with the given message. times 0 fails every call.
+//
+// param: path
+// param: message
+// param: times
+func (s *Server) Fail(path string, message string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = &failure{status: http.StatusOK, message: message, remaining: times}
+}
+
+// FailHTTP - let the next times calls of path return the given http status. times 0 fails every call.
+//
+// param: path
+// param: status
+// param: times
+func (s *Server) FailHTTP(path string, status int, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = &failure{status: status, message: http.StatusText(status), remaining: times}
+}
+
+// Calls - number of calls of path received so far
+//
+// param: path
+// return: int
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+// serveHTTP - dispatch a request to the handler of its path
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/"+BasePath+"/")
+	params, err := readParams(r)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, sumamodels.RespAPI{Message: err.Error()})
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[path]++
+	if f, ok := s.failures[path]; ok {
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				delete(s.failures, path)
+			}
+		}
+		writeResponse(w, f.status, sumamodels.RespAPI{Message: f.message})
+		return
+	}
+	switch path {
+	case "auth/login":
+		s.doLogin(w, params)
+		return
+	case "auth/logout":
+		s.doLogout(w, r)
+		return
+	}
+	if !s.authenticated(r) {
+		writeResponse(w, http.StatusUnauthorized, sumamodels.RespAPI{Message: "Authentication failed"})
+		return
+	}
+	h, ok := s.handlers[path]
+	if !ok {
+		writeResponse(w, http.StatusNotFound, sumamodels.RespAPI{Message: fmt.Sprintf("no such api call %v", path)})
+		return
+	}
+	result, err := h(params)
+	if err != nil {
+		writeResponse(w, http.StatusOK, sumamodels.RespAPI{Message: err.Error()})
+		return
+	}
+	writeResponse(w, http.StatusOK, sumamodels.RespAPISuccess{Success: true, Result: result})
+}
+
+// doLogin - check the credentials and hand out a new session cookie. Like the real server, the valid session cookie
+// is the third cookie of the response.
+func (s *Server) doLogin(w http.ResponseWriter, params map[string]any) {
+	if str(params, "login") != s.login || str(params, "password") != s.password {
+		writeResponse(w, http.StatusOK, sumamodels.RespAPI{Message: "Either the password or username is incorrect."})
+		return
+	}
+	key := fmt.Sprintf("%x", time.Now().UnixNano()+int64(s.newID()))
+	s.sessions[key] = true
+	http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: key, Path: "/"})
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Path: "/", MaxAge: -1})
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: key, Path: "/"})
+	writeResponse(w, http.StatusOK, sumamodels.RespAPISuccess{Success: true})
+}
+
+// doLogout - invalidate the session of the request
+func (s *Server) doLogout(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(SessionCookie); err == nil {
+		delete(s.sessions, c.Value)
+	}
+	writeResponse(w, http.StatusOK, sumamodels.RespAPISuccess{Success: true, Result: 1})
+}
+
+// authenticated - check the session cookie of the request
+func (s *Server) authenticated(r *http.Request) bool {
+	c, err := r.Cookie(SessionCookie)
+	return err == nil && s.sessions[c.Value]
+}
+
+// ExpireSessions - invalidate all sessions, as the server does when their lifetime passed
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]bool)
+}
+
+// Sessions - number of currently valid sessions
+//
+// return: int
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// newID - next free id, ids are unique over all objects
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// readParams - merge the query parameters and the json body of the request
+func readParams(r *http.Request) (map[string]any, error) {
+	params := make(map[string]any)
+	for key, values := range r.URL.Query() {
+		params[key] = values[0]
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &params); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// writeResponse - send the json encoded response
+func writeResponse(w http.ResponseWriter, status int, resp any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// str - string parameter
+func str(params map[string]any, key string) string {
+	switch v := params[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// num - integer parameter
+func num(params map[string]any, key string) int {
+	switch v := params[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		var i int
+		_, _ = fmt.Sscan(v, &i)
+		return i
+	default:
+		return 0
+	}
+}
+
+// nums - integer list parameter
+func nums(params map[string]any, key string) []int {
+	var result []int
+	list, _ := params[key].([]any)
+	for _, v := range list {
+		if f, ok := v.(float64); ok {
+			result = append(result, int(f))
+		}
+	}
+	return result
+}
+
+// flag - boolean parameter
+func flag(params map[string]any, key string) bool {
+	v, _ := params[key].(bool)
+	return v
+}
+
+// now - current time in a format the api models can parse
+func now() sumamodels.CustomDate {
+	return sumamodels.CustomDate(time.Now().UTC().Truncate(time.Second))
+}
@@ -0,0 +1,144 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// registerSync - sync.slave and sync.master api calls
+func (s *Server) registerSync() {
+	s.handlers["sync/slave/getSlaves"] = s.syncGetSlaves
+	s.handlers["sync/slave/getSlaveByName"] = s.syncGetSlaveByName
+	s.handlers["sync/slave/create"] = s.syncSlaveCreate
+	s.handlers["sync/slave/delete"] = s.syncSlaveDelete
+	s.handlers["sync/master/getMasterByLabel"] = s.syncGetMasterByLabel
+	s.handlers["sync/master/create"] = s.syncMasterCreate
+	s.handlers["sync/master/delete"] = s.syncMasterDelete
+	s.handlers["sync/master/makeDefault"] = s.syncMasterMakeDefault
+	s.handlers["sync/master/setCaCert"] = s.syncMasterSetCaCert
+}
+
+// Slaves - all registered secondaries
+//
+// return: []Slaves
+func (s *Server) Slaves() []sumamodels.Slaves {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedSlaves()
+}
+
+// Masters - all registered primaries
+//
+// return: []SlavesIssMaster
+func (s *Server) Masters() []sumamodels.SlavesIssMaster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []sumamodels.SlavesIssMaster
+	for _, master := range s.masters {
+		result = append(result, master)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (s *Server) syncGetSlaves(_ map[string]any) (any, error) {
+	return append([]sumamodels.Slaves{}, s.sortedSlaves()...), nil
+}
+
+func (s *Server) syncGetSlaveByName(params map[string]any) (any, error) {
+	fqdn := str(params, "slaveFqdn")
+	for _, slave := range s.slaves {
+		if slave.Label == fqdn {
+			return slave, nil
+		}
+	}
+	return nil, fmt.Errorf("no such slave %v", fqdn)
+}
+
+func (s *Server) syncSlaveCreate(params map[string]any) (any, error) {
+	fqdn := str(params, "slaveFqdn")
+	for _, slave := range s.slaves {
+		if slave.Label == fqdn {
+			return nil, fmt.Errorf("slave %v already exists", fqdn)
+		}
+	}
+	slave := sumamodels.Slaves{ID: s.newID(), Label: fqdn, Enabled: flag(params, "isEnabled"), AllowedAllOrgs: flag(params, "allowAllOrgs")}
+	s.slaves[slave.ID] = slave
+	return slave, nil
+}
+
+func (s *Server) syncSlaveDelete(params map[string]any) (any, error) {
+	id := num(params, "slaveId")
+	if _, ok := s.slaves[id]; !ok {
+		return nil, fmt.Errorf("no such slave %v", id)
+	}
+	delete(s.slaves, id)
+	return 1, nil
+}
+
+func (s *Server) syncGetMasterByLabel(params map[string]any) (any, error) {
+	label := str(params, "label")
+	for _, master := range s.masters {
+		if master.Label == label {
+			return master, nil
+		}
+	}
+	return nil, fmt.Errorf("no such master %v", label)
+}
+
+func (s *Server) syncMasterCreate(params map[string]any) (any, error) {
+	label := str(params, "label")
+	for _, master := range s.masters {
+		if master.Label == label {
+			return nil, fmt.Errorf("master %v already exists", label)
+		}
+	}
+	master := sumamodels.SlavesIssMaster{ID: s.newID(), Label: label}
+	s.masters[master.ID] = master
+	return master, nil
+}
+
+func (s *Server) syncMasterDelete(params map[string]any) (any, error) {
+	id := num(params, "masterId")
+	if _, ok := s.masters[id]; !ok {
+		return nil, fmt.Errorf("no such master %v", id)
+	}
+	delete(s.masters, id)
+	return 1, nil
+}
+
+func (s *Server) syncMasterMakeDefault(params map[string]any) (any, error) {
+	id := num(params, "masterId")
+	if _, ok := s.masters[id]; !ok {
+		return nil, fmt.Errorf("no such master %v", id)
+	}
+	for key, master := range s.masters {
+		master.Master = key == id
+		s.masters[key] = master
+	}
+	return 1, nil
+}
+
+func (s *Server) syncMasterSetCaCert(params map[string]any) (any, error) {
+	id := num(params, "masterId")
+	master, ok := s.masters[id]
+	if !ok {
+		return nil, fmt.Errorf("no such master %v", id)
+	}
+	master.CaCert = str(params, "caCertFilename")
+	s.masters[id] = master
+	return 1, nil
+}
+
+// sortedSlaves - all secondaries ordered by id
+func (s *Server) sortedSlaves() []sumamodels.Slaves {
+	var result []sumamodels.Slaves
+	for _, slave := range s.slaves {
+		result = append(result, slave)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
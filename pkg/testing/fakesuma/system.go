@@ -0,0 +1,291 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// System - registered system
+type System struct {
+	ID          int
+	Name        string
+	BaseChannel string
+	Errata      []sumamodels.Errata
+	Upgradable  []sumamodels.UpgradablePackage
+	Installed   []sumamodels.InstalledPackage
+	// LastCheckin - time of the last check in, the current time when not set
+	LastCheckin sumamodels.CustomDate
+	// ConnectionPath - names of the proxies the system connects through, the proxy nearest to the system first
+	ConnectionPath []string
+	// Inactive - system did not check in for a while
+	Inactive bool
+	// MigrationTargets - product migration targets available for the system
+	MigrationTargets []sumamodels.MigrationTarget
+}
+
+// registerSystem - system api calls
+func (s *Server) registerSystem() {
+	s.handlers["system/getId"] = s.systemGetID
+	s.handlers["system/listSystems"] = s.systemListSystems
+	s.handlers["system/listActiveSystems"] = s.systemListActiveSystems
+	s.handlers["system/getSubscribedBaseChannel"] = s.systemGetSubscribedBaseChannel
+	s.handlers["system/getRelevantErrata"] = s.systemGetRelevantErrata
+	s.handlers["system/listLatestUpgradablePackages"] = s.systemListLatestUpgradablePackages
+	s.handlers["system/listInstalledPackages"] = s.systemListInstalledPackages
+	s.handlers["system/scheduleApplyErrata"] = s.systemScheduleApplyErrata
+	s.handlers["system/schedulePackageInstall"] = s.systemSchedulePackageInstall
+	s.handlers["system/scheduleReboot"] = s.systemSchedule("System reboot", "Reboot")
+	s.handlers["system/schedulePackageRefresh"] = s.systemSchedule("Package List Refresh", "Package List Refresh")
+	s.handlers["system/scheduleScriptRun"] = s.systemScheduleScriptRun
+	s.handlers["system/getConnectionPath"] = s.systemGetConnectionPath
+	s.handlers["system/obtainReactivationKey"] = s.systemObtainReactivationKey
+	s.handlers["system/listMigrationTargets"] = s.systemListMigrationTargets
+	s.handlers["proxy/listProxyClients"] = s.proxyListProxyClients
+	s.handlers["system/scheduleApplyHighstate"] = s.systemSchedule("Apply highstate", "Apply states")
+	s.handlers["system/scheduleApplyStates"] = s.systemSchedule("Apply states", "Apply states")
+}
+
+// AddSystem - register a system, the id is assigned when 0
+//
+// param: system
+// return: system id
+func (s *Server) AddSystem(system System) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if system.ID == 0 {
+		system.ID = s.newID()
+	}
+	s.systems[system.ID] = &system
+	return system.ID
+}
+
+// GetSystem - lookup a system
+//
+// param: systemID
+// return: system, found
+func (s *Server) GetSystem(systemID int) (System, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	system, ok := s.systems[systemID]
+	if !ok {
+		return System{}, false
+	}
+	return *system, true
+}
+
+func (s *Server) systemGetID(params map[string]any) (any, error) {
+	result := []sumamodels.System{}
+	for _, system := range s.sortedSystems() {
+		if system.Name == str(params, "name") {
+			result = append(result, sumamodels.System{ID: system.ID, Name: system.Name, LastChekin: system.lastCheckin()})
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) systemListSystems(_ map[string]any) (any, error) {
+	result := []sumamodels.System{}
+	for _, system := range s.sortedSystems() {
+		result = append(result, sumamodels.System{ID: system.ID, Name: system.Name, LastChekin: system.lastCheckin(), OutdatedPkgCount: len(system.Upgradable)})
+	}
+	return result, nil
+}
+
+func (s *Server) systemListActiveSystems(_ map[string]any) (any, error) {
+	result := []sumamodels.ActiveSystem{}
+	for _, system := range s.sortedSystems() {
+		result = append(result, sumamodels.ActiveSystem{ID: system.ID, Name: system.Name, LastChekin: system.lastCheckin(), Created: now(), LastBoot: now()})
+	}
+	return result, nil
+}
+
+func (s *Server) systemGetSubscribedBaseChannel(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	channel, ok := s.channels[system.BaseChannel]
+	if !ok {
+		return sumamodels.SubscribedBaseChannel{}, nil
+	}
+	return sumamodels.SubscribedBaseChannel{ID: channel.ID, Label: channel.Label, Name: channel.Name, Summary: channel.Summary, ArchLabel: channel.ArchLabel}, nil
+}
+
+func (s *Server) systemGetRelevantErrata(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]sumamodels.Errata{}, system.Errata...), nil
+}
+
+func (s *Server) systemListLatestUpgradablePackages(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]sumamodels.UpgradablePackage{}, system.Upgradable...), nil
+}
+
+func (s *Server) systemListInstalledPackages(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]sumamodels.InstalledPackage{}, system.Installed...), nil
+}
+
+func (s *Server) systemScheduleApplyErrata(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	errataIDs := nums(params, "errataIds")
+	id := s.scheduleAction(system.ID, "Patch Update", "Patch Update", func() {
+		var remaining []sumamodels.Errata
+		for _, errata := range system.Errata {
+			if !containsInt(errataIDs, errata.ID) {
+				remaining = append(remaining, errata)
+			}
+		}
+		system.Errata = remaining
+	})
+	return []int{id}, nil
+}
+
+func (s *Server) systemSchedulePackageInstall(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	packageIDs := nums(params, "packageIds")
+	return s.scheduleAction(system.ID, "Package Install", "Package Install", func() {
+		var remaining []sumamodels.UpgradablePackage
+		for _, pkg := range system.Upgradable {
+			if containsInt(packageIDs, pkg.ToPackageID) {
+				system.Installed = append(system.Installed, sumamodels.InstalledPackage{PackageID: pkg.ToPackageID, Name: pkg.Name, Version: pkg.ToVersion, Release: pkg.ToRelease, Epoch: pkg.ToEpoch, Arch: pkg.Arch})
+				continue
+			}
+			remaining = append(remaining, pkg)
+		}
+		system.Upgradable = remaining
+	}), nil
+}
+
+func (s *Server) systemScheduleScriptRun(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	script := str(params, "script")
+	id := s.scheduleAction(system.ID, "Run an arbitrary script", "Run an arbitrary script", func() {
+		if s.RunScript != nil {
+			s.RunScript(system, script)
+		}
+	})
+	s.actions[id].Script = script
+	return id, nil
+}
+
+func (s *Server) systemGetConnectionPath(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.SystemConnectionPath{}
+	for i, name := range system.ConnectionPath {
+		hop := sumamodels.SystemConnectionPath{Position: i + 1, Hostname: name}
+		for _, proxy := range s.systems {
+			if proxy.Name == name {
+				hop.ID = proxy.ID
+			}
+		}
+		result = append(result, hop)
+	}
+	return result, nil
+}
+
+func (s *Server) systemObtainReactivationKey(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("re-1-%x", system.ID*7919+s.newID()), nil
+}
+
+func (s *Server) systemListMigrationTargets(params map[string]any) (any, error) {
+	system, err := s.system(num(params, "sid"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]sumamodels.MigrationTarget{}, system.MigrationTargets...), nil
+}
+
+func (s *Server) proxyListProxyClients(params map[string]any) (any, error) {
+	proxy, err := s.system(num(params, "proxyId"))
+	if err != nil {
+		return nil, err
+	}
+	result := []int{}
+	for _, system := range s.sortedSystems() {
+		for _, name := range system.ConnectionPath {
+			if name == proxy.Name {
+				result = append(result, system.ID)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// systemSchedule - handler scheduling an action without further effect on the system
+func (s *Server) systemSchedule(name string, actionType string) handler {
+	return func(params map[string]any) (any, error) {
+		system, err := s.system(num(params, "sid"))
+		if err != nil {
+			return nil, err
+		}
+		return s.scheduleAction(system.ID, name, actionType, nil), nil
+	}
+}
+
+// lastCheckin - time of the last check in of the system
+func (system *System) lastCheckin() sumamodels.CustomDate {
+	if time.Time(system.LastCheckin).IsZero() {
+		return now()
+	}
+	return system.LastCheckin
+}
+
+// system - lookup a system
+func (s *Server) system(systemID int) (*System, error) {
+	system, ok := s.systems[systemID]
+	if !ok {
+		return nil, fmt.Errorf("no such system - sid = %v", systemID)
+	}
+	return system, nil
+}
+
+// sortedSystems - all systems ordered by id
+func (s *Server) sortedSystems() []*System {
+	var result []*System
+	for _, system := range s.systems {
+		result = append(result, system)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// containsInt - check if the list contains the value
+func containsInt(list []int, value int) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Package fakesuma - in-memory fake of the SUSE Multi-Linux Manager JSON API for offline tests
+package fakesuma
+
+import (
+	"fmt"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+)
+
+// group - system group
+type group struct {
+	id        int
+	systemIDs []int
+}
+
+// registerSystemGroup - system group api calls
+func (s *Server) registerSystemGroup() {
+	s.handlers["systemgroup/getDetails"] = s.systemGroupGetDetails
+	s.handlers["systemgroup/listSystemsMinimal"] = s.systemGroupListSystemsMinimal
+	s.handlers["systemgroup/listActiveSystemsInGroup"] = s.systemGroupListActiveSystemsInGroup
+}
+
+// AddGroup - create a system group with the given systems
+//
+// param: name
+// param: systemIDs
+// return: group id
+func (s *Server) AddGroup(name string, systemIDs ...int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := &group{id: s.newID(), systemIDs: systemIDs}
+	s.groups[name] = g
+	return g.id
+}
+
+func (s *Server) systemGroupGetDetails(params map[string]any) (any, error) {
+	name := str(params, "systemGroupName")
+	g, err := s.group(name)
+	if err != nil {
+		return nil, err
+	}
+	return sumamodels.SystemGroupGetDetails{ID: g.id, Name: name, SystemCount: len(g.systemIDs)}, nil
+}
+
+func (s *Server) systemGroupListSystemsMinimal(params map[string]any) (any, error) {
+	g, err := s.group(str(params, "systemGroupName"))
+	if err != nil {
+		return nil, err
+	}
+	result := []sumamodels.SystemGroupListSystemsMinimal{}
+	for _, id := range g.systemIDs {
+		if system, ok := s.systems[id]; ok {
+			result = append(result, sumamodels.SystemGroupListSystemsMinimal{ID: system.ID, Name: system.Name, LastChekin: system.lastCheckin(), Created: now(), LastBoot: now(), OutdatedPkgCount: len(system.Upgradable)})
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) systemGroupListActiveSystemsInGroup(params map[string]any) (any, error) {
+	g, err := s.group(str(params, "systemGroupName"))
+	if err != nil {
+		return nil, err
+	}
+	result := []int{}
+	for _, id := range g.systemIDs {
+		if system, ok := s.systems[id]; ok && !system.Inactive {
+			result = append(result, system.ID)
+		}
+	}
+	return result, nil
+}
+
+// group - lookup a system group
+func (s *Server) group(name string) (*group, error) {
+	g, ok := s.groups[name]
+	if !ok {
+		return nil, fmt.Errorf("unable to locate or access server group: %v", name)
+	}
+	return g, nil
+}
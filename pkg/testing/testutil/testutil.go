@@ -0,0 +1,18 @@
+// Package testutil - helpers shared by the tests of all packages
+package testutil
+
+import (
+	"os"
+	"testing"
+
+	"mlmtool/pkg/models/inputfile"
+	log "mlmtool/pkg/util/logger"
+)
+
+// Main - run the tests of a package with the logger set up to print errors only. To be called from TestMain.
+//
+// param: m
+func Main(m *testing.M) {
+	_ = log.InitLogger(inputfile.Config{LogLevel: inputfile.Loglevel{Screen: "error"}})
+	os.Exit(m.Run())
+}
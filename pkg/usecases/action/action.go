@@ -0,0 +1,228 @@
+package action
+
+import (
+	"fmt"
+	ac "mlmtool/pkg/models/action"
+	"mlmtool/pkg/models/inputfile"
+	"sort"
+	"strings"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+const (
+	// CommandList lists the scheduled actions
+	CommandList = "list"
+	// CommandShow shows an action with the result per system
+	CommandShow = "show"
+	// CommandCancel cancels actions
+	CommandCancel = "cancel"
+	// CommandReschedule reschedules failed actions
+	CommandReschedule = "reschedule"
+)
+
+const (
+	// StateAll selects all actions, except the archived ones
+	StateAll = "all"
+	// StateInProgress selects the actions in progress on one or more systems
+	StateInProgress = "inprogress"
+	// StateFailed selects the actions failed on one or more systems
+	StateFailed = "failed"
+	// StateCompleted selects the actions completed on all systems
+	StateCompleted = "completed"
+	// StateArchived selects the archived actions
+	StateArchived = "archived"
+)
+
+type Action struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                ac.InputData
+}
+
+func NewAction(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input ac.InputData) *Action {
+	return &Action{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// Action performs the requested command on the scheduled actions: list the actions in a state, show an action with
+// the result and output per system, cancel actions, or reschedule actions on the failed or all systems.
+func (h *Action) Action() error {
+	log.Debug("Action started")
+	err := h.validateAction()
+	if err != nil {
+		return err
+	}
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	switch h.input.Command {
+	case CommandList:
+		err = h.listActions(authParm)
+	case CommandShow:
+		err = h.showAction(authParm)
+	case CommandCancel:
+		err = h.sumanProxy.ScheduleCancelActions(authParm, h.input.ActionIDs)
+		if err == nil {
+			fmt.Printf("actions %v canceled\n", formatIDs(h.input.ActionIDs))
+		}
+	case CommandReschedule:
+		err = h.sumanProxy.ScheduleRescheduleActions(authParm, h.input.ActionIDs, !h.input.AllSystems)
+		if err == nil {
+			fmt.Printf("actions %v rescheduled\n", formatIDs(h.input.ActionIDs))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("Action finished")
+	return nil
+}
+
+// validateAction checks the parameters needed for the requested command are given.
+func (h *Action) validateAction() error {
+	log.Debug("action validateAction started")
+	switch h.input.Command {
+	case CommandList:
+		switch h.input.State {
+		case StateAll, StateInProgress, StateFailed, StateCompleted, StateArchived:
+		default:
+			return fmt.Errorf("unknown state %v, should be one of %v", h.input.State,
+				strings.Join([]string{StateAll, StateInProgress, StateFailed, StateCompleted, StateArchived}, ", "))
+		}
+	case CommandShow:
+		if len(h.input.ActionIDs) != 1 {
+			return fmt.Errorf("exactly one action id is mandatory")
+		}
+	case CommandCancel, CommandReschedule:
+		if len(h.input.ActionIDs) == 0 {
+			return fmt.Errorf("action ids are mandatory")
+		}
+	default:
+		return fmt.Errorf("unknown command %v", h.input.Command)
+	}
+	log.Debug("action validateAction finished")
+	return nil
+}
+
+// actions returns the actions in the given state, most recent first.
+func (h *Action) actions(authParm _sumanUseCase.AuthParams, state string) ([]sumamodels.ScheduleAction, error) {
+	var actions []sumamodels.ScheduleAction
+	var err error
+	switch state {
+	case StateInProgress:
+		actions, err = h.sumanProxy.ScheduleListInProgressActions(authParm)
+	case StateFailed:
+		actions, err = h.sumanProxy.ScheduleListFailedActions(authParm)
+	case StateCompleted:
+		actions, err = h.sumanProxy.ScheduleListCompletedActions(authParm)
+	case StateArchived:
+		actions, err = h.sumanProxy.ScheduleListArchivedActions(authParm)
+	default:
+		actions, err = h.sumanProxy.ScheduleListAllActions(authParm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].ID > actions[j].ID })
+	return actions, nil
+}
+
+// listActions prints the actions in the requested state.
+func (h *Action) listActions(authParm _sumanUseCase.AuthParams) error {
+	actions, err := h.actions(authParm, h.input.State)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-8s %-20s %-12s %-10s %-8s %-6s %s\n", "id", "earliest", "scheduler", "progress", "done", "failed", "name")
+	for _, action := range actions {
+		fmt.Printf("%-8d %-20s %-12s %-10d %-8d %-6d %s\n", action.ID, formatDate(action.Earliest), action.Scheduler,
+			action.InProgressSystems, action.CompletedSystems, action.FailedSystems, action.Name)
+	}
+	return nil
+}
+
+// showAction prints the action with the result and output per system.
+func (h *Action) showAction(authParm _sumanUseCase.AuthParams) error {
+	actionID := h.input.ActionIDs[0]
+	var found *sumamodels.ScheduleAction
+	for _, state := range []string{StateAll, StateArchived} {
+		actions, err := h.actions(authParm, state)
+		if err != nil {
+			return err
+		}
+		for i := range actions {
+			if actions[i].ID == actionID {
+				found = &actions[i]
+				break
+			}
+		}
+		if found != nil {
+			break
+		}
+	}
+	if found == nil {
+		return fmt.Errorf("action %v not found", actionID)
+	}
+	fmt.Printf("id: %v\nname: %v\ntype: %v\nscheduler: %v\nearliest: %v\n", found.ID, found.Name, found.Type, found.Scheduler, formatDate(found.Earliest))
+	if found.Prerequisite > 0 {
+		fmt.Printf("prerequisite: %v\n", found.Prerequisite)
+	}
+	lists := []struct {
+		state string
+		list  func(_sumanUseCase.AuthParams, int) ([]sumamodels.ScheduleActionSystem, error)
+	}{
+		{"in progress", h.sumanProxy.ScheduleListInProgressSystems},
+		{"completed", h.sumanProxy.ScheduleListCompletedSystems},
+		{"failed", h.sumanProxy.ScheduleListFailedSystems},
+	}
+	for _, l := range lists {
+		systems, err := l.list(authParm, actionID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v: %v\n", l.state, len(systems))
+		for _, system := range systems {
+			fmt.Printf("    %-40s %s\n", system.ServerName, formatDate(system.Timestamp))
+			message := strings.TrimSpace(system.Message)
+			if len(message) > 0 {
+				fmt.Printf("        %v\n", strings.ReplaceAll(message, "\n", "\n        "))
+			}
+		}
+	}
+	return nil
+}
+
+// formatDate returns the date as local time, or an empty string when not set.
+func formatDate(date sumamodels.CustomDate) string {
+	t := time.Time(date)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatIDs returns the action ids as comma separated list.
+func formatIDs(ids []int) string {
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprint(id))
+	}
+	return strings.Join(parts, ", ")
+}
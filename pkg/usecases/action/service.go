@@ -0,0 +1,5 @@
+package action
+
+type IAction interface {
+	Action() error
+}
@@ -0,0 +1,147 @@
+package cleanupProfiles
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	cp "mlmtool/pkg/models/cleanupProfiles"
+	"mlmtool/pkg/models/inputfile"
+	"os"
+	"strings"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/consts"
+	util "mlmtool/pkg/util/contains"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// staleProfile - an autoinstallation profile to be removed, with the reason why
+type staleProfile struct {
+	label  string
+	reason string
+}
+
+type CleanupProfiles struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                cp.InputData
+}
+
+func NewCleanupProfiles(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input cp.InputData) *CleanupProfiles {
+	return &CleanupProfiles{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// CleanupProfiles removes the stale autoinstallation profiles. A profile is stale when its distribution, or the
+// channel of its distribution, doesn't exist anymore or when it isn't one of the known autoyast types. The profiles
+// are removed after confirmation, or directly when yes is given. With dry run, the profiles are only printed.
+func (h *CleanupProfiles) CleanupProfiles() error {
+	log.Debug("CleanupProfiles started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	stale, err := h.findStaleProfiles(authParm)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Println("no stale autoinstallation profiles found")
+		log.Info("CleanupProfiles finished")
+		return nil
+	}
+	fmt.Println("the following autoinstallation profiles are stale:")
+	for _, profile := range stale {
+		fmt.Printf("    %-40s %s\n", profile.label, profile.reason)
+	}
+	if h.input.DryRun {
+		fmt.Println("dry run, no profiles removed")
+		log.Info("CleanupProfiles finished")
+		return nil
+	}
+	if !h.input.Yes && !confirm(fmt.Sprintf("remove %v profile(s)?", len(stale))) {
+		fmt.Println("no profiles removed")
+		return nil
+	}
+	err = h.doCleanupProfiles(authParm, stale)
+	if err != nil {
+		return err
+	}
+	log.Info("CleanupProfiles finished")
+	return nil
+}
+
+// findStaleProfiles returns the autoinstallation profiles to be removed. Only distributions and channels reported as
+// not found by the api make a profile stale, any other error is returned before anything is removed.
+func (h *CleanupProfiles) findStaleProfiles(authParm _sumanUseCase.AuthParams) ([]staleProfile, error) {
+	log.Debug("cleanupProfiles findStaleProfiles started")
+	profiles, err := h.sumanProxy.KickstartListKickstarts(authParm)
+	if err != nil {
+		return nil, err
+	}
+	types := strings.Fields(consts.AutoyastTypes)
+	var stale []staleProfile
+	for _, profile := range profiles {
+		if !util.Contains(types, profile.Label) {
+			stale = append(stale, staleProfile{label: profile.Label, reason: "not a known autoyast type"})
+			continue
+		}
+		tree, err := h.sumanProxy.KickstartTreeGetDetails(authParm, profile.TreeLabel)
+		if errors.Is(err, _sumanUseCase.ErrNotFound) {
+			stale = append(stale, staleProfile{label: profile.Label, reason: fmt.Sprintf("distribution %v doesn't exist", profile.TreeLabel)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("checking distribution %v of profile %v: %w", profile.TreeLabel, profile.Label, err)
+		}
+		_, err = h.sumanProxy.ChannelSoftwareGetDetailsByID(authParm, tree.ChannelID)
+		if errors.Is(err, _sumanUseCase.ErrNotFound) {
+			stale = append(stale, staleProfile{label: profile.Label, reason: fmt.Sprintf("channel of distribution %v doesn't exist", profile.TreeLabel)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("checking channel of distribution %v of profile %v: %w", profile.TreeLabel, profile.Label, err)
+		}
+	}
+	log.Debug("cleanupProfiles findStaleProfiles finished")
+	return stale, nil
+}
+
+// doCleanupProfiles removes the given profiles. All profiles are tried, the failures are returned as one error.
+func (h *CleanupProfiles) doCleanupProfiles(authParm _sumanUseCase.AuthParams, stale []staleProfile) error {
+	log.Debug("doCleanupProfiles started")
+	var failed []string
+	for _, profile := range stale {
+		log.Info(fmt.Sprintf("removing autoinstallation profile %v", profile.label))
+		_, err := h.sumanProxy.KickstartDeleteProfile(authParm, profile.label)
+		if err != nil {
+			log.Error(fmt.Sprintf("unable to remove autoinstallation profile %v: %v", profile.label, err))
+			failed = append(failed, profile.label)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to remove autoinstallation profiles %v", strings.Join(failed, ", "))
+	}
+	log.Debug("doCleanupProfiles finished")
+	return nil
+}
+
+// confirm asks the question on stdout and returns true when it is answered with y or yes.
+func confirm(question string) bool {
+	fmt.Printf("%v [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
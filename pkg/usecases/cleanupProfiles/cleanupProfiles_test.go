@@ -0,0 +1,99 @@
+package cleanupProfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cp "mlmtool/pkg/models/cleanupProfiles"
+	"mlmtool/pkg/models/inputfile"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestCleanupProfiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            cp.InputData
+		setup            func(fake *fakesuma.Server)
+		expectError      bool
+		expectedProfiles []string
+	}{
+		{
+			name:             "profile of missing distribution removed",
+			input:            cp.InputData{Yes: true},
+			setup:            func(fake *fakesuma.Server) { fake.AddKickstartProfile("SL_SERVER-s154", "sles15sp4-tree") },
+			expectedProfiles: []string{"SL_SERVER-s155"},
+		},
+		{
+			name:  "profile of missing channel removed",
+			input: cp.InputData{Yes: true},
+			setup: func(fake *fakesuma.Server) {
+				fake.AddKickstartTree("sles15sp4-tree", "sles15-sp4-pool-x86_64")
+				fake.AddKickstartProfile("SL_SERVER-s154", "sles15sp4-tree")
+			},
+			expectedProfiles: []string{"SL_SERVER-s155"},
+		},
+		{
+			name:             "unknown profile removed",
+			input:            cp.InputData{Yes: true},
+			setup:            func(fake *fakesuma.Server) { fake.AddKickstartProfile("old-profile", "sles15sp5-tree") },
+			expectedProfiles: []string{"SL_SERVER-s155"},
+		},
+		{
+			name:             "dry run removes nothing",
+			input:            cp.InputData{DryRun: true},
+			setup:            func(fake *fakesuma.Server) { fake.AddKickstartProfile("SL_SERVER-s154", "sles15sp4-tree") },
+			expectedProfiles: []string{"SL_SERVER-s154", "SL_SERVER-s155"},
+		},
+		{
+			name:  "failed distribution lookup removes nothing",
+			input: cp.InputData{Yes: true},
+			setup: func(fake *fakesuma.Server) {
+				fake.AddKickstartProfile("SL_SERVER-s154", "sles15sp4-tree")
+				fake.Fail("kickstart/tree/getDetails", "internal server error", 0)
+			},
+			expectError:      true,
+			expectedProfiles: []string{"SL_SERVER-s154", "SL_SERVER-s155"},
+		},
+		{
+			name:  "failed channel lookup removes nothing",
+			input: cp.InputData{Yes: true},
+			setup: func(fake *fakesuma.Server) {
+				fake.AddKickstartProfile("SL_SERVER-s154", "sles15sp4-tree")
+				fake.Fail("channel/software/getDetails", "internal server error", 0)
+			},
+			expectError:      true,
+			expectedProfiles: []string{"SL_SERVER-s154", "SL_SERVER-s155"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.AddChannel("sles15-sp5-pool-x86_64", "")
+			fake.AddKickstartTree("sles15sp5-tree", "sles15-sp5-pool-x86_64")
+			fake.AddKickstartProfile("SL_SERVER-s155", "sles15sp5-tree")
+			tt.setup(fake)
+			proxy := fake.Proxy()
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			h := NewCleanupProfiles(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 60, genConfig, tt.input)
+
+			err := h.CleanupProfiles()
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Zero(t, fake.Calls("kickstart/deleteProfile"))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedProfiles, fake.KickstartProfiles())
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package cleanupProfiles
+
+type ICleanupProfiles interface {
+	CleanupProfiles() error
+}
@@ -0,0 +1,269 @@
+package createAutoyastProfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	cap "mlmtool/pkg/models/createAutoyastProfile"
+	"mlmtool/pkg/models/inputfile"
+	rs "mlmtool/pkg/models/registerSystem"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/consts"
+	util "mlmtool/pkg/util/contains"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// the labels used while an existing profile is replaced
+const (
+	newProfileSuffix = "-new"
+	oldProfileSuffix = "-old"
+)
+
+type CreateAutoyastProfile struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                cap.InputData
+}
+
+func NewCreateAutoyastProfile(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input cap.InputData) *CreateAutoyastProfile {
+	return &CreateAutoyastProfile{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// CreateAutoyastProfile (re)creates the autoyast profiles of the given types, default all types in consts.AutoyastTypes.
+// Per type the template <type>.xml from the autoyast dir is rendered, the distribution is created when missing, the
+// rendered profile is imported with its variables and replaces an existing profile once the import succeeded.
+// Returns an error when one or more profiles could not be created.
+func (h *CreateAutoyastProfile) CreateAutoyastProfile() error {
+	log.Debug("CreateAutoyastProfile started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	types, releases, err := h.validateCreateAutoyastProfile()
+	if err != nil {
+		return err
+	}
+	var failed []string
+	for _, profileType := range types {
+		err = h.doCreateAutoyastProfile(authParm, profileType, releases)
+		if err != nil {
+			log.Error(fmt.Sprintf("unable to create autoyast profile %v: %v", profileType, err))
+			failed = append(failed, profileType)
+			continue
+		}
+		fmt.Printf("autoyast profile %v created\n", profileType)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to create autoyast profiles %v", strings.Join(failed, ", "))
+	}
+	log.Info("CreateAutoyastProfile finished")
+	return nil
+}
+
+// validateCreateAutoyastProfile checks the given types are known and reads osreleasedata.json. When no file is
+// given, the file is read from dirs.scripts_dir.
+func (h *CreateAutoyastProfile) validateCreateAutoyastProfile() ([]string, []rs.OsRelease, error) {
+	log.Debug("createAutoyastProfile validateCreateAutoyastProfile started")
+	knownTypes := strings.Fields(consts.AutoyastTypes)
+	types := h.input.Types
+	if len(types) == 0 {
+		types = knownTypes
+	}
+	for _, profileType := range types {
+		if !util.Contains(knownTypes, profileType) {
+			return nil, nil, fmt.Errorf("unknown autoyast type %v, known types are %v", profileType, consts.AutoyastTypes)
+		}
+	}
+	if len(h.input.Dir) == 0 {
+		h.input.Dir = consts.DefaultAutoyastDir
+	}
+	fileName := h.input.OsDataFile
+	if len(fileName) == 0 {
+		fileName = filepath.Join(h.genConfig.Dirs.ScriptsDir, "osreleasedata.json")
+	}
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read %v: %v", fileName, err)
+	}
+	var osData rs.OsReleaseData
+	err = json.Unmarshal(data, &osData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse %v: %v", fileName, err)
+	}
+	log.Debug("createAutoyastProfile validateCreateAutoyastProfile finished")
+	return types, osData.OsRelease, nil
+}
+
+// doCreateAutoyastProfile creates the profile of the given type. Types named <role>-<os label> use the distribution
+// named after the os label, which is created from osreleasedata.json when missing. Other types use the distribution
+// consts.AutoyastDistribution, which has to exist. An existing profile is kept until the new profile is imported
+// under a temporary label, then the new profile is swapped in.
+func (h *CreateAutoyastProfile) doCreateAutoyastProfile(authParm _sumanUseCase.AuthParams, profileType string, releases []rs.OsRelease) error {
+	log.Debug(fmt.Sprintf("doCreateAutoyastProfile started for %v", profileType))
+	templateData := cap.TemplateData{
+		Server:       h.genConfig.Suman.Server,
+		Type:         profileType,
+		Distribution: consts.AutoyastDistribution,
+	}
+	if _, osLabel, found := strings.Cut(profileType, "-"); found {
+		release, err := findRelease(releases, osLabel)
+		if err != nil {
+			return err
+		}
+		templateData.OsLabel = osLabel
+		templateData.Distribution = osLabel
+		templateData.ParentChannel = release.ParentChannel
+		err = h.ensureDistribution(authParm, release)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err := h.sumanProxy.KickstartTreeGetDetails(authParm, templateData.Distribution)
+		if err != nil {
+			return fmt.Errorf("distribution %v doesn't exist", templateData.Distribution)
+		}
+	}
+	profile, err := h.renderTemplate(templateData)
+	if err != nil {
+		return err
+	}
+	profiles, err := h.sumanProxy.KickstartListKickstarts(authParm)
+	if err != nil {
+		return err
+	}
+	importLabel := profileType
+	for _, existing := range profiles {
+		switch existing.Label {
+		case profileType:
+			importLabel = profileType + newProfileSuffix
+		case profileType + newProfileSuffix:
+			log.Info(fmt.Sprintf("removing leftover autoyast profile %v", existing.Label))
+			_, err = h.sumanProxy.KickstartDeleteProfile(authParm, existing.Label)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	log.Info(fmt.Sprintf("importing autoyast profile %v with distribution %v", importLabel, templateData.Distribution))
+	err = h.importProfile(authParm, profileType, importLabel, templateData.Distribution, profile)
+	if err != nil {
+		if importLabel != profileType {
+			_, _ = h.sumanProxy.KickstartDeleteProfile(authParm, importLabel)
+		}
+		return err
+	}
+	if importLabel != profileType {
+		err = h.replaceProfile(authParm, profileType, importLabel)
+		if err != nil {
+			return err
+		}
+	}
+	log.Debug(fmt.Sprintf("doCreateAutoyastProfile finished for %v", profileType))
+	return nil
+}
+
+// importProfile imports the rendered profile of the type under the given label and sets the variables of the type.
+func (h *CreateAutoyastProfile) importProfile(authParm _sumanUseCase.AuthParams, profileType string, label string, distribution string, profile string) error {
+	_, err := h.sumanProxy.KickstartImportRawFile(authParm, label, consts.VirtType, distribution, profile)
+	if err != nil {
+		return err
+	}
+	for _, variables := range consts.ProfileVariables {
+		if variables.ProfileName == profileType {
+			_, err = h.sumanProxy.KickstartProfileSetVariables(authParm, label, variables.ProfileVars)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceProfile swaps the imported profile in for the existing profile of the type. The existing profile is renamed
+// out of the way first and renamed back when the imported profile can't take over its label.
+func (h *CreateAutoyastProfile) replaceProfile(authParm _sumanUseCase.AuthParams, profileType string, importLabel string) error {
+	oldLabel := profileType + oldProfileSuffix
+	log.Info(fmt.Sprintf("replacing existing autoyast profile %v", profileType))
+	_, err := h.sumanProxy.KickstartRenameProfile(authParm, profileType, oldLabel)
+	if err != nil {
+		_, _ = h.sumanProxy.KickstartDeleteProfile(authParm, importLabel)
+		return fmt.Errorf("unable to rename existing profile %v to %v: %v", profileType, oldLabel, err)
+	}
+	_, err = h.sumanProxy.KickstartRenameProfile(authParm, importLabel, profileType)
+	if err != nil {
+		_, restoreErr := h.sumanProxy.KickstartRenameProfile(authParm, oldLabel, profileType)
+		if restoreErr != nil {
+			log.Error(fmt.Sprintf("unable to restore autoyast profile %v from %v: %v", profileType, oldLabel, restoreErr))
+		}
+		return fmt.Errorf("unable to rename imported profile %v to %v: %v", importLabel, profileType, err)
+	}
+	_, err = h.sumanProxy.KickstartDeleteProfile(authParm, oldLabel)
+	if err != nil {
+		return fmt.Errorf("unable to remove previous profile %v: %v", oldLabel, err)
+	}
+	return nil
+}
+
+// ensureDistribution creates the distribution of the os label when it doesn't exist yet.
+func (h *CreateAutoyastProfile) ensureDistribution(authParm _sumanUseCase.AuthParams, release rs.OsRelease) error {
+	_, err := h.sumanProxy.KickstartTreeGetDetails(authParm, release.Label)
+	if err == nil {
+		return nil
+	}
+	if len(release.TreePath) == 0 {
+		return fmt.Errorf("no tree path given for os label %v", release.Label)
+	}
+	log.Info(fmt.Sprintf("creating distribution %v from %v", release.Label, release.TreePath))
+	_, err = h.sumanProxy.KickstartTreeCreate(authParm, release.Label, release.TreePath, release.ParentChannel, consts.AutoyastInstallType)
+	if err != nil {
+		return fmt.Errorf("unable to create distribution %v: %v", release.Label, err)
+	}
+	return nil
+}
+
+// renderTemplate renders the template <type>.xml from the autoyast dir.
+func (h *CreateAutoyastProfile) renderTemplate(templateData cap.TemplateData) (string, error) {
+	fileName := filepath.Join(h.input.Dir, templateData.Type+".xml")
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return "", fmt.Errorf("unable to read template %v: %v", fileName, err)
+	}
+	tmpl, err := template.New(templateData.Type).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template %v: %v", fileName, err)
+	}
+	var profile bytes.Buffer
+	err = tmpl.Execute(&profile, templateData)
+	if err != nil {
+		return "", fmt.Errorf("unable to render template %v: %v", fileName, err)
+	}
+	return profile.String(), nil
+}
+
+// findRelease returns the entry of osreleasedata.json for the os label.
+func findRelease(releases []rs.OsRelease, osLabel string) (rs.OsRelease, error) {
+	for _, release := range releases {
+		if release.Label == osLabel {
+			return release, nil
+		}
+	}
+	return rs.OsRelease{}, fmt.Errorf("os label %v not found in osreleasedata.json", osLabel)
+}
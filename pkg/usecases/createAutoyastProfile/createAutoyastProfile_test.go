@@ -0,0 +1,105 @@
+package createAutoyastProfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cap "mlmtool/pkg/models/createAutoyastProfile"
+	"mlmtool/pkg/models/inputfile"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/consts"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestCreateAutoyastProfile(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         []string
+		setup            func(fake *fakesuma.Server)
+		expectError      bool
+		expectedProfiles []string
+		expectedImports  int
+	}{
+		{
+			name:             "profile created",
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+		{
+			name:             "existing profile replaced",
+			existing:         []string{"dtag_server"},
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+		{
+			name:             "leftover import removed",
+			existing:         []string{"dtag_server", "dtag_server-new"},
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+		{
+			name:             "existing profile kept when import fails",
+			existing:         []string{"dtag_server"},
+			setup:            func(fake *fakesuma.Server) { fake.Fail("kickstart/importRawFile", "invalid profile", 0) },
+			expectError:      true,
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+		{
+			name:             "existing profile kept when variables fail",
+			existing:         []string{"dtag_server"},
+			setup:            func(fake *fakesuma.Server) { fake.Fail("kickstart/profile/setVariables", "invalid variables", 0) },
+			expectError:      true,
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+		{
+			name:             "existing profile kept when rename fails",
+			existing:         []string{"dtag_server"},
+			setup:            func(fake *fakesuma.Server) { fake.Fail("kickstart/renameProfile", "rename failed", 1) },
+			expectError:      true,
+			expectedProfiles: []string{"dtag_server"},
+			expectedImports:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "dtag_server.xml"), []byte("<profile>{{.Server}}</profile>"), 0o600))
+			osDataFile := filepath.Join(dir, "osreleasedata.json")
+			assert.NoError(t, os.WriteFile(osDataFile, []byte(`{"osRelease": []}`), 0o600))
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.AddKickstartTree(consts.AutoyastDistribution, "")
+			for _, label := range tt.existing {
+				fake.AddKickstartProfile(label, consts.AutoyastDistribution)
+			}
+			if tt.setup != nil {
+				tt.setup(fake)
+			}
+			proxy := fake.Proxy()
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			input := cap.InputData{Types: []string{"dtag_server"}, Dir: dir, OsDataFile: osDataFile}
+			h := NewCreateAutoyastProfile(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 60, genConfig, input)
+
+			err := h.CreateAutoyastProfile()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedProfiles, fake.KickstartProfiles())
+			assert.Equal(t, tt.expectedImports, fake.Calls("kickstart/importRawFile"))
+		})
+	}
+}
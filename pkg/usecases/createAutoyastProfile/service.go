@@ -0,0 +1,5 @@
+package createAutoyastProfile
+
+type ICreateAutoyastProfile interface {
+	CreateAutoyastProfile() error
+}
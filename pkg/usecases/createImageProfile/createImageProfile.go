@@ -0,0 +1,160 @@
+package createImageProfile
+
+import (
+	"fmt"
+	cip "mlmtool/pkg/models/createImageProfile"
+	"mlmtool/pkg/models/inputfile"
+	"os"
+	"path/filepath"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TypeDockerfile = "dockerfile"
+	TypeKiwi       = "kiwi"
+)
+
+type CreateImageProfile struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                cip.InputData
+}
+
+func NewCreateImageProfile(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input cip.InputData) *CreateImageProfile {
+	return &CreateImageProfile{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// CreateImageProfile creates the image profiles listed in the given YAML file. Profiles that already exist with the
+// same settings are left alone. Profiles that exist with other settings are recreated when replace is given,
+// otherwise an error is returned.
+func (h *CreateImageProfile) CreateImageProfile() error {
+	log.Debug("CreateImageProfile started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	profiles, err := h.validateCreateImageProfile(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doCreateImageProfile(authParm, profiles)
+	if err != nil {
+		return err
+	}
+	log.Info("CreateImageProfile finished")
+	return nil
+}
+
+// validateCreateImageProfile reads the YAML file and checks the type, image store and activation key of every profile.
+func (h *CreateImageProfile) validateCreateImageProfile(authParm _sumanUseCase.AuthParams) ([]cip.ProfileSpec, error) {
+	log.Debug("createImageProfile validateCreateImageProfile started")
+	if len(h.input.File) == 0 {
+		return nil, fmt.Errorf("file is mandatory")
+	}
+	data, err := os.ReadFile(filepath.Clean(h.input.File))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %v: %v", h.input.File, err)
+	}
+	var profilesFile cip.ProfilesFile
+	err = yaml.Unmarshal(data, &profilesFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %v: %v", h.input.File, err)
+	}
+	if len(profilesFile.Profiles) == 0 {
+		return nil, fmt.Errorf("no profiles found in %v", h.input.File)
+	}
+	stores, err := h.sumanProxy.ImageStoreListImageStores(authParm)
+	if err != nil {
+		return nil, err
+	}
+	knownStores := map[string]bool{}
+	for _, store := range stores {
+		knownStores[store.Label] = true
+	}
+	for i, profile := range profilesFile.Profiles {
+		if len(profile.Label) == 0 || len(profile.Store) == 0 || len(profile.Path) == 0 {
+			return nil, fmt.Errorf("profile %v should have a label, store and path", i+1)
+		}
+		if profile.Type != TypeDockerfile && profile.Type != TypeKiwi {
+			return nil, fmt.Errorf("profile %v has type %v, which should be %v or %v", profile.Label, profile.Type, TypeDockerfile, TypeKiwi)
+		}
+		if len(profile.KiwiOptions) > 0 && profile.Type != TypeKiwi {
+			return nil, fmt.Errorf("profile %v has kiwi options, which are only used with type %v", profile.Label, TypeKiwi)
+		}
+		if !knownStores[profile.Store] {
+			return nil, fmt.Errorf("image store %v of profile %v doesn't exist", profile.Store, profile.Label)
+		}
+		if len(profile.ActivationKey) > 0 {
+			_, err = h.sumanProxy.ActivationKeyGetDetails(authParm, profile.ActivationKey)
+			if err != nil {
+				return nil, fmt.Errorf("activation key %v of profile %v doesn't exist", profile.ActivationKey, profile.Label)
+			}
+		}
+	}
+	log.Debug("createImageProfile validateCreateImageProfile finished")
+	return profilesFile.Profiles, nil
+}
+
+// doCreateImageProfile creates the missing profiles and, with replace, recreates the changed ones.
+func (h *CreateImageProfile) doCreateImageProfile(authParm _sumanUseCase.AuthParams, profiles []cip.ProfileSpec) error {
+	log.Debug("doCreateImageProfile started")
+	existing, err := h.sumanProxy.ImageProfileListImageProfiles(authParm)
+	if err != nil {
+		return err
+	}
+	current := map[string]sumamodels.ImageProfile{}
+	for _, profile := range existing {
+		current[profile.Label] = profile
+	}
+	for _, profile := range profiles {
+		if existingProfile, ok := current[profile.Label]; ok {
+			if sameProfile(existingProfile, profile) {
+				fmt.Printf("image profile %v is up to date\n", profile.Label)
+				continue
+			}
+			if !h.input.Replace {
+				return fmt.Errorf("image profile %v already exists with other settings, use --replace to recreate it", profile.Label)
+			}
+			log.Info(fmt.Sprintf("removing image profile %v", profile.Label))
+			err = h.sumanProxy.ImageProfileDelete(authParm, profile.Label)
+			if err != nil {
+				return err
+			}
+		}
+		log.Info(fmt.Sprintf("creating %v image profile %v", profile.Type, profile.Label))
+		err = h.sumanProxy.ImageProfileCreate(authParm, profile.Label, profile.Type, profile.Store, profile.Path, profile.ActivationKey, profile.KiwiOptions)
+		if err != nil {
+			return fmt.Errorf("unable to create image profile %v: %v", profile.Label, err)
+		}
+		fmt.Printf("image profile %v created\n", profile.Label)
+	}
+	log.Debug("doCreateImageProfile finished")
+	return nil
+}
+
+// sameProfile returns true when the existing profile has the settings of the listed profile.
+func sameProfile(existing sumamodels.ImageProfile, profile cip.ProfileSpec) bool {
+	return existing.ImageType == profile.Type &&
+		existing.ImageStore == profile.Store &&
+		existing.Path == profile.Path &&
+		existing.ActivationKey == profile.ActivationKey &&
+		existing.KiwiOptions == profile.KiwiOptions
+}
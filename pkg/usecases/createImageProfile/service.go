@@ -0,0 +1,5 @@
+package createImageProfile
+
+type ICreateImageProfile interface {
+	CreateImageProfile() error
+}
@@ -0,0 +1,367 @@
+package createRepos
+
+import (
+	"fmt"
+	cr "mlmtool/pkg/models/createRepos"
+	"mlmtool/pkg/models/inputfile"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultArch     = "channel-x86_64"
+	defaultRepoType = "yum"
+)
+
+type CreateRepos struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                cr.InputData
+	changes              []string
+}
+
+func NewCreateRepos(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input cr.InputData) *CreateRepos {
+	return &CreateRepos{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// CreateRepos makes the custom channels and repositories on SUSE Manager match the given YAML file. Missing channels
+// and repositories are created, changed URLs and GPG settings are updated and the repositories are associated with
+// their channels. With delete enabled, channels and repositories starting with the prefix but not listed are removed.
+// Finally the sync of all listed channels is triggered.
+func (h *CreateRepos) CreateRepos() error {
+	log.Debug("CreateRepos started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	spec, err := h.validateCreateRepos(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doCreateRepos(authParm, spec)
+	h.printSummary()
+	if err != nil {
+		return err
+	}
+	log.Info("CreateRepos finished")
+	return nil
+}
+
+// validateCreateRepos reads the YAML file, fills in the defaults and checks the channels and repositories are
+// complete and their parent channels exist, either on SUSE Manager or earlier in the file.
+func (h *CreateRepos) validateCreateRepos(authParm _sumanUseCase.AuthParams) (cr.RepoSpec, error) {
+	log.Debug("createRepos validateCreateRepos started")
+	var spec cr.RepoSpec
+	if len(h.input.File) == 0 {
+		return spec, fmt.Errorf("file is mandatory")
+	}
+	data, err := os.ReadFile(filepath.Clean(h.input.File))
+	if err != nil {
+		return spec, fmt.Errorf("unable to read %v: %v", h.input.File, err)
+	}
+	err = yaml.Unmarshal(data, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("unable to parse %v: %v", h.input.File, err)
+	}
+	if len(spec.Channels) == 0 {
+		return spec, fmt.Errorf("no channels found in %v", h.input.File)
+	}
+	if h.input.Delete && len(spec.Prefix) == 0 {
+		return spec, fmt.Errorf("a prefix is mandatory in %v when deleting unlisted channels and repositories", h.input.File)
+	}
+	existing, err := h.sumanProxy.ChannelListSoftwareChannels(authParm)
+	if err != nil {
+		return spec, err
+	}
+	known := map[string]bool{}
+	for _, channel := range existing {
+		known[channel.Label] = true
+	}
+	repoURLs := map[string]string{}
+	listed := map[string]bool{}
+	for i := range spec.Channels {
+		channel := &spec.Channels[i]
+		if len(channel.Label) == 0 {
+			return spec, fmt.Errorf("channel %v has no label", i+1)
+		}
+		if listed[channel.Label] {
+			return spec, fmt.Errorf("channel %v is listed more than once", channel.Label)
+		}
+		if len(channel.Parent) > 0 && !listed[channel.Parent] && !known[channel.Parent] {
+			return spec, fmt.Errorf("parent channel %v of channel %v doesn't exist", channel.Parent, channel.Label)
+		}
+		listed[channel.Label] = true
+		if len(channel.Name) == 0 {
+			channel.Name = channel.Label
+		}
+		if len(channel.Summary) == 0 {
+			channel.Summary = channel.Name
+		}
+		if len(channel.Arch) == 0 {
+			channel.Arch = defaultArch
+		}
+		for j := range channel.Repos {
+			repo := &channel.Repos[j]
+			if len(repo.Label) == 0 || len(repo.URL) == 0 {
+				return spec, fmt.Errorf("repository %v of channel %v should have a label and url", j+1, channel.Label)
+			}
+			// a repository can be associated with more than one channel, but only with a single url
+			if url, ok := repoURLs[repo.Label]; ok && url != repo.URL {
+				return spec, fmt.Errorf("repository %v is listed with different urls", repo.Label)
+			}
+			repoURLs[repo.Label] = repo.URL
+			if len(repo.Type) == 0 {
+				repo.Type = defaultRepoType
+			}
+			if len(repo.SslCaCert) == 0 && (len(repo.SslCliCert) > 0 || len(repo.SslCliKey) > 0) {
+				return spec, fmt.Errorf("repository %v has a client certificate without a ca certificate", repo.Label)
+			}
+		}
+	}
+	log.Debug("createRepos validateCreateRepos finished")
+	return spec, nil
+}
+
+// doCreateRepos reconciles the repositories and channels, deletes the unlisted ones when requested and triggers the sync.
+func (h *CreateRepos) doCreateRepos(authParm _sumanUseCase.AuthParams, spec cr.RepoSpec) error {
+	log.Debug("doCreateRepos started")
+	repos, err := h.sumanProxy.ChannelSoftwareListUserRepos(authParm)
+	if err != nil {
+		return err
+	}
+	existingRepos := map[string]sumamodels.ChannelSoftwareCreateRepo{}
+	for _, repo := range repos {
+		existingRepos[repo.Label] = repo
+	}
+	for _, channel := range spec.Channels {
+		for _, repo := range channel.Repos {
+			err = h.reconcileRepo(authParm, repo, existingRepos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, channel := range spec.Channels {
+		err = h.reconcileChannel(authParm, channel)
+		if err != nil {
+			return err
+		}
+	}
+	if h.input.Delete {
+		err = h.deleteUnlisted(authParm, spec, existingRepos)
+		if err != nil {
+			return err
+		}
+	}
+	for _, channel := range spec.Channels {
+		if len(channel.Repos) == 0 {
+			continue
+		}
+		log.Info(fmt.Sprintf("triggering sync of channel %v", channel.Label))
+		_, err = h.sumanProxy.ChannelSoftwareSyncRepo(authParm, channel.Label)
+		if err != nil {
+			return fmt.Errorf("unable to sync channel %v: %v", channel.Label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("sync triggered for channel %v", channel.Label))
+	}
+	log.Debug("doCreateRepos finished")
+	return nil
+}
+
+// reconcileRepo creates the repository when missing, or updates its url when changed.
+func (h *CreateRepos) reconcileRepo(authParm _sumanUseCase.AuthParams, repo cr.RepoItem, existingRepos map[string]sumamodels.ChannelSoftwareCreateRepo) error {
+	current, ok := existingRepos[repo.Label]
+	if !ok {
+		log.Info(fmt.Sprintf("creating repository %v", repo.Label))
+		created, err := h.sumanProxy.ChannelSoftwareCreateRepo(authParm, repo.Label, repo.Type, repo.URL, repo.SslCaCert, repo.SslCliCert, repo.SslCliKey)
+		if err != nil {
+			return fmt.Errorf("unable to create repository %v: %v", repo.Label, err)
+		}
+		existingRepos[repo.Label] = created
+		h.changes = append(h.changes, fmt.Sprintf("repository %v created", repo.Label))
+		return nil
+	}
+	if current.SourceURL != repo.URL {
+		log.Info(fmt.Sprintf("updating url of repository %v to %v", repo.Label, repo.URL))
+		err := h.sumanProxy.ChannelSoftwareUpdateRepoURL(authParm, repo.Label, repo.URL)
+		if err != nil {
+			return fmt.Errorf("unable to update repository %v: %v", repo.Label, err)
+		}
+		current.SourceURL = repo.URL
+		existingRepos[repo.Label] = current
+		h.changes = append(h.changes, fmt.Sprintf("repository %v url changed to %v", repo.Label, repo.URL))
+	}
+	return nil
+}
+
+// reconcileChannel creates the channel when missing, updates its details when changed and associates the listed
+// repositories. With delete enabled, repositories associated with the channel but not listed are disassociated.
+func (h *CreateRepos) reconcileChannel(authParm _sumanUseCase.AuthParams, channel cr.ChannelSpec) error {
+	exists, err := h.sumanProxy.ChannelSoftwareIsExisting(authParm, channel.Label)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Info(fmt.Sprintf("creating channel %v", channel.Label))
+		_, err = h.sumanProxy.ChannelSoftwareCreate(authParm, channel.Label, channel.Name, channel.Summary, channel.Arch, channel.Parent)
+		if err != nil {
+			return fmt.Errorf("unable to create channel %v: %v", channel.Label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("channel %v created", channel.Label))
+	}
+	details, err := h.sumanProxy.ChannelSoftwareGetDetails(authParm, channel.Label)
+	if err != nil {
+		return err
+	}
+	if details.ParentChannelLabel != channel.Parent {
+		return fmt.Errorf("channel %v has parent %v instead of %v, which cannot be changed", channel.Label, details.ParentChannelLabel, channel.Parent)
+	}
+	changed := map[string]interface{}{}
+	if details.Name != channel.Name {
+		changed["name"] = channel.Name
+	}
+	if details.Summary != channel.Summary {
+		changed["summary"] = channel.Summary
+	}
+	if details.GpgKeyURL != channel.GpgURL {
+		changed["gpg_key_url"] = channel.GpgURL
+	}
+	if details.GpgKeyID != channel.GpgID {
+		changed["gpg_key_id"] = channel.GpgID
+	}
+	if details.GpgKeyfp != channel.GpgFp {
+		changed["gpg_key_fp"] = channel.GpgFp
+	}
+	if channel.GpgCheck != nil && details.GpgCheck != *channel.GpgCheck {
+		changed["gpg_check"] = *channel.GpgCheck
+	}
+	if len(changed) > 0 {
+		log.Info(fmt.Sprintf("updating details of channel %v", channel.Label))
+		err = h.sumanProxy.ChannelSoftwareSetDetails(authParm, channel.Label, changed)
+		if err != nil {
+			return fmt.Errorf("unable to update channel %v: %v", channel.Label, err)
+		}
+		var keys []string
+		for key := range changed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		h.changes = append(h.changes, fmt.Sprintf("channel %v updated: %v", channel.Label, strings.Join(keys, ", ")))
+	}
+	associated := map[string]bool{}
+	for _, source := range details.ContentSource {
+		associated[source.Label] = true
+	}
+	listed := map[string]bool{}
+	for _, repo := range channel.Repos {
+		listed[repo.Label] = true
+		if associated[repo.Label] {
+			continue
+		}
+		log.Info(fmt.Sprintf("associating repository %v with channel %v", repo.Label, channel.Label))
+		_, err = h.sumanProxy.ChannelSoftwareAssociateRepo(authParm, channel.Label, repo.Label)
+		if err != nil {
+			return fmt.Errorf("unable to associate repository %v with channel %v: %v", repo.Label, channel.Label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("repository %v associated with channel %v", repo.Label, channel.Label))
+	}
+	if !h.input.Delete {
+		return nil
+	}
+	for label := range associated {
+		if listed[label] {
+			continue
+		}
+		log.Info(fmt.Sprintf("disassociating repository %v from channel %v", label, channel.Label))
+		err = h.sumanProxy.ChannelSoftwareDisassociateRepo(authParm, channel.Label, label)
+		if err != nil {
+			return fmt.Errorf("unable to disassociate repository %v from channel %v: %v", label, channel.Label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("repository %v disassociated from channel %v", label, channel.Label))
+	}
+	return nil
+}
+
+// deleteUnlisted deletes the channels and repositories starting with the prefix which are not listed. Child channels
+// are deleted before their parents, the repositories after the channels they could be associated with.
+func (h *CreateRepos) deleteUnlisted(authParm _sumanUseCase.AuthParams, spec cr.RepoSpec, existingRepos map[string]sumamodels.ChannelSoftwareCreateRepo) error {
+	listedChannels := map[string]bool{}
+	listedRepos := map[string]bool{}
+	for _, channel := range spec.Channels {
+		listedChannels[channel.Label] = true
+		for _, repo := range channel.Repos {
+			listedRepos[repo.Label] = true
+		}
+	}
+	channels, err := h.sumanProxy.ChannelListSoftwareChannels(authParm)
+	if err != nil {
+		return err
+	}
+	var children, parents []string
+	for _, channel := range channels {
+		if !strings.HasPrefix(channel.Label, spec.Prefix) || listedChannels[channel.Label] {
+			continue
+		}
+		if len(channel.ParentLabel) > 0 {
+			children = append(children, channel.Label)
+		} else {
+			parents = append(parents, channel.Label)
+		}
+	}
+	for _, label := range append(children, parents...) {
+		log.Info(fmt.Sprintf("deleting channel %v", label))
+		err = h.sumanProxy.ChannelSoftwareDelete(authParm, label)
+		if err != nil {
+			return fmt.Errorf("unable to delete channel %v: %v", label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("channel %v deleted", label))
+	}
+	var repos []string
+	for label := range existingRepos {
+		if strings.HasPrefix(label, spec.Prefix) && !listedRepos[label] {
+			repos = append(repos, label)
+		}
+	}
+	sort.Strings(repos)
+	for _, label := range repos {
+		log.Info(fmt.Sprintf("removing repository %v", label))
+		err = h.sumanProxy.ChannelSoftwareRemoveRepo(authParm, label)
+		if err != nil {
+			return fmt.Errorf("unable to remove repository %v: %v", label, err)
+		}
+		h.changes = append(h.changes, fmt.Sprintf("repository %v removed", label))
+	}
+	return nil
+}
+
+// printSummary prints the changes made, in the order they were made.
+func (h *CreateRepos) printSummary() {
+	if len(h.changes) == 0 {
+		fmt.Println("no changes needed")
+		return
+	}
+	for _, change := range h.changes {
+		fmt.Println(change)
+	}
+}
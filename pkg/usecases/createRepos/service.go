@@ -0,0 +1,5 @@
+package createRepos
+
+type ICreateRepos interface {
+	CreateRepos() error
+}
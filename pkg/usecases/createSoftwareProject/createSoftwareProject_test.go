@@ -0,0 +1,105 @@
+package createSoftwareProject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	csp "mlmtool/pkg/models/createSoftwareProject"
+	"mlmtool/pkg/models/inputfile"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestCreateSoftwareProject(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        csp.InputData
+		setup        func(fake *fakesuma.Server)
+		expectError  bool
+		expectedEnvs []string
+		expectedSrcs []string
+	}{
+		{
+			name:         "new project with all children",
+			input:        csp.InputData{Project: "sles15", Environment: "dev,test,prod", BaseChannel: "sles15-pool"},
+			expectedEnvs: []string{"dev", "test", "prod"},
+			expectedSrcs: []string{"sles15-pool", "sles15-updates", "sles15-web"},
+		},
+		{
+			name:         "new project with selected children",
+			input:        csp.InputData{Project: "sles15", Environment: "dev", BaseChannel: "sles15-pool", AddChannel: "sles15-updates,sles15-web", DeleteChannel: "sles15-web"},
+			expectedEnvs: []string{"dev"},
+			expectedSrcs: []string{"sles15-pool", "sles15-updates"},
+		},
+		{
+			name:  "existing project adds and deletes channels",
+			input: csp.InputData{Project: "sles15", Environment: "dev", BaseChannel: "sles15-pool", AddChannel: "sles15-web", DeleteChannel: "sles15-updates"},
+			setup: func(fake *fakesuma.Server) {
+				fake.AddProject("sles15", "dev", "prod")
+				proxy := fake.Proxy()
+				sessionKey, _ := proxy.SumanLogin()
+				auth := _sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: sessionKey}
+				_, _ = proxy.ContentManagementAttachSource(auth, "sles15", "software", "sles15-pool")
+				_, _ = proxy.ContentManagementAttachSource(auth, "sles15", "software", "sles15-updates")
+			},
+			expectedEnvs: []string{"dev", "prod"},
+			expectedSrcs: []string{"sles15-pool", "sles15-web"},
+		},
+		{
+			name:        "missing environment",
+			input:       csp.InputData{Project: "sles15", BaseChannel: "sles15-pool"},
+			expectError: true,
+		},
+		{
+			name:        "unknown basechannel",
+			input:       csp.InputData{Project: "sles15", Environment: "dev", BaseChannel: "sles12-pool"},
+			expectError: true,
+		},
+		{
+			name:  "environment creation fails",
+			input: csp.InputData{Project: "sles15", Environment: "dev,prod", BaseChannel: "sles15-pool"},
+			setup: func(fake *fakesuma.Server) {
+				fake.Fail("contentmanagement/createEnvironment", "environment creation failed", 1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.AddChannel("sles15-pool", "")
+			fake.AddChannel("sles15-updates", "sles15-pool")
+			fake.AddChannel("sles15-web", "sles15-pool")
+			if tt.setup != nil {
+				tt.setup(fake)
+			}
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			proxy := fake.Proxy()
+			h := NewCreateSoftwareProject(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 60, genConfig, tt.input)
+
+			err := h.CreateSoftwareProject()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			_, found := fake.Project(tt.input.Project)
+			assert.True(t, found)
+			var envs []string
+			for _, env := range fake.Environments(tt.input.Project) {
+				envs = append(envs, env.Label)
+			}
+			assert.Equal(t, tt.expectedEnvs, envs)
+			assert.ElementsMatch(t, tt.expectedSrcs, fake.Sources(tt.input.Project))
+		})
+	}
+}
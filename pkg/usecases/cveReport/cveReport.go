@@ -0,0 +1,320 @@
+package cveReport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	cvr "mlmtool/pkg/models/cveReport"
+	"mlmtool/pkg/models/inputfile"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+const (
+	// FormatTable prints the report as table
+	FormatTable = "table"
+	// FormatCSV prints the report as comma separated values
+	FormatCSV = "csv"
+	// FormatJSON prints the report as JSON
+	FormatJSON = "json"
+
+	// notAffected is the patch status of systems not affected by a CVE
+	notAffected = "NOT_AFFECTED"
+)
+
+type CveReport struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                cvr.InputData
+}
+
+func NewCveReport(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input cvr.InputData) *CveReport {
+	return &CveReport{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// CveReport lists for the given CVEs every affected system, the patch fixing it, the patch status on the system
+// and the content lifecycle environments the patch is available in. The report is written as table, CSV or JSON.
+func (h *CveReport) CveReport() error {
+	log.Debug("CveReport started")
+	cves, err := h.validateCveReport()
+	if err != nil {
+		return err
+	}
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	records, environments, err := h.doCveReport(authParm, cves)
+	if err != nil {
+		return err
+	}
+	err = h.writeReport(records, environments)
+	if err != nil {
+		return err
+	}
+	log.Info("CveReport finished")
+	return nil
+}
+
+// validateCveReport checks the format and returns the CVEs given on the command line and in the given file.
+func (h *CveReport) validateCveReport() ([]string, error) {
+	log.Debug("cveReport validateCveReport started")
+	switch h.input.Format {
+	case FormatTable, FormatCSV, FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown format %v, use %v, %v or %v", h.input.Format, FormatTable, FormatCSV, FormatJSON)
+	}
+	var cves []string
+	for _, cve := range h.input.Cves {
+		if cve = strings.TrimSpace(cve); len(cve) > 0 {
+			cves = append(cves, strings.ToUpper(cve))
+		}
+	}
+	if len(h.input.File) > 0 {
+		fileCves, err := readCveFile(h.input.File)
+		if err != nil {
+			return nil, err
+		}
+		cves = append(cves, fileCves...)
+	}
+	if len(cves) == 0 {
+		return nil, fmt.Errorf("at least one cve is mandatory")
+	}
+	log.Debug("cveReport validateCveReport finished")
+	return cves, nil
+}
+
+// readCveFile reads the CVEs from the given file, one per line. Empty lines and lines starting with # are ignored.
+func readCveFile(fileName string) ([]string, error) {
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %v: %v", fileName, err)
+	}
+	defer func() { _ = file.Close() }()
+	var cves []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cves = append(cves, strings.ToUpper(line))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read %v: %v", fileName, err)
+	}
+	return cves, nil
+}
+
+// doCveReport collects the records for all CVEs. It returns the records and the sorted list of all
+// content lifecycle environments, labeled <project>-<environment>.
+func (h *CveReport) doCveReport(authParm _sumanUseCase.AuthParams, cves []string) ([]cvr.Record, []string, error) {
+	log.Debug("doCveReport started")
+	environments, err := h.listEnvironments(authParm)
+	if err != nil {
+		return nil, nil, err
+	}
+	systemNames, err := h.systemNames(authParm)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the environments a patch is available in, per advisory
+	patchEnvironments := map[string]map[string]bool{}
+	records := []cvr.Record{}
+	for _, cve := range cves {
+		log.Debug(fmt.Sprintf("collecting patch status for %v", cve))
+		systems, err := h.sumanProxy.AuditListSystemsByPatchStatus(authParm, cve)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to get patch status for %v: %v", cve, err)
+		}
+		for _, system := range systems {
+			if system.PatchStatus == notAffected {
+				continue
+			}
+			name, ok := systemNames[system.SystemID]
+			if !ok {
+				name = strconv.Itoa(system.SystemID)
+			}
+			advisories := system.ErrataAdvisories
+			if len(advisories) == 0 {
+				advisories = []string{""}
+			}
+			for _, advisory := range advisories {
+				record := cvr.Record{Cve: cve, System: name, SystemID: system.SystemID, PatchStatus: system.PatchStatus, Patch: advisory}
+				if len(advisory) > 0 {
+					if _, ok := patchEnvironments[advisory]; !ok {
+						patchEnvironments[advisory], err = h.availableIn(authParm, advisory, environments)
+						if err != nil {
+							return nil, nil, err
+						}
+					}
+					record.Environments = patchEnvironments[advisory]
+				}
+				records = append(records, record)
+			}
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Cve != records[j].Cve {
+			return records[i].Cve < records[j].Cve
+		}
+		return records[i].System < records[j].System
+	})
+	log.Debug("doCveReport finished")
+	return records, environments, nil
+}
+
+// listEnvironments returns all content lifecycle environments, labeled <project>-<environment>.
+func (h *CveReport) listEnvironments(authParm _sumanUseCase.AuthParams) ([]string, error) {
+	projects, err := h.sumanProxy.ContentManagementListProjects(authParm)
+	if err != nil {
+		return nil, err
+	}
+	var environments []string
+	for _, project := range projects {
+		projectEnvironments, err := h.suse.OrderedEnvironments(authParm, project.Label)
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range projectEnvironments {
+			environments = append(environments, fmt.Sprintf("%v-%v", project.Label, environment.Label))
+		}
+	}
+	sort.Strings(environments)
+	return environments, nil
+}
+
+// systemNames returns the names of all active systems by their id.
+func (h *CveReport) systemNames(authParm _sumanUseCase.AuthParams) (map[int]string, error) {
+	systems, err := h.sumanProxy.SystemListActiveSystems(authParm)
+	if err != nil {
+		return nil, err
+	}
+	names := map[int]string{}
+	for _, system := range systems {
+		names[system.ID] = system.Name
+	}
+	return names, nil
+}
+
+// availableIn checks for every environment if the patch is available in one of its channels.
+// The channels of an environment are labeled <project>-<environment>-<channel>.
+func (h *CveReport) availableIn(authParm _sumanUseCase.AuthParams, advisory string, environments []string) (map[string]bool, error) {
+	channels, err := h.sumanProxy.ErrataApplicableToChannels(authParm, advisory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get channels of patch %v: %v", advisory, err)
+	}
+	available := map[string]bool{}
+	for _, environment := range environments {
+		available[environment] = false
+		for _, channel := range channels {
+			if strings.HasPrefix(channel.Label, environment+"-") {
+				available[environment] = true
+				break
+			}
+		}
+	}
+	return available, nil
+}
+
+// writeReport writes the records in the requested format to the output file, or to stdout when no file is given.
+func (h *CveReport) writeReport(records []cvr.Record, environments []string) error {
+	var out io.Writer = os.Stdout
+	if len(h.input.Output) > 0 {
+		file, err := os.Create(filepath.Clean(h.input.Output))
+		if err != nil {
+			return fmt.Errorf("unable to create %v: %v", h.input.Output, err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+	switch h.input.Format {
+	case FormatCSV:
+		return writeCSV(out, records, environments)
+	case FormatJSON:
+		return writeJSON(out, records)
+	default:
+		return writeTable(out, records)
+	}
+}
+
+// writeTable writes the records as table, listing the environments the patch is available in.
+func writeTable(out io.Writer, records []cvr.Record) error {
+	format := "%-16s %-40s %-28s %-30s %s\n"
+	_, err := fmt.Fprintf(out, format, "cve", "system", "patch status", "patch", "available in")
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		var available []string
+		for environment, ok := range record.Environments {
+			if ok {
+				available = append(available, environment)
+			}
+		}
+		sort.Strings(available)
+		patch := record.Patch
+		if len(patch) == 0 {
+			patch = "-"
+		}
+		_, err = fmt.Fprintf(out, format, record.Cve, record.System, record.PatchStatus, patch, strings.Join(available, ", "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the records as CSV, with a yes/no column per environment.
+func writeCSV(out io.Writer, records []cvr.Record, environments []string) error {
+	writer := csv.NewWriter(out)
+	header := append([]string{"cve", "system", "system_id", "patch_status", "patch"}, environments...)
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		line := []string{record.Cve, record.System, strconv.Itoa(record.SystemID), record.PatchStatus, record.Patch}
+		for _, environment := range environments {
+			available := "no"
+			if record.Environments[environment] {
+				available = "yes"
+			}
+			line = append(line, available)
+		}
+		err = writer.Write(line)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSON writes the records as JSON array.
+func writeJSON(out io.Writer, records []cvr.Record) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
@@ -0,0 +1,5 @@
+package cveReport
+
+type ICveReport interface {
+	CveReport() error
+}
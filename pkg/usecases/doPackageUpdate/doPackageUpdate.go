@@ -0,0 +1,282 @@
+package doPackageUpdate
+
+import (
+	"fmt"
+	dpu "mlmtool/pkg/models/doPackageUpdate"
+	"mlmtool/pkg/models/inputfile"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	util "mlmtool/pkg/util/contains"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// transactionalChannels are parts of the base channel labels of transactional systems, which are updated with
+// transactional-update and need a reboot to activate the new snapshot
+var transactionalChannels = []string{"micro"}
+
+// systemResult - outcome of the package update of a single system
+type systemResult struct {
+	name          string
+	systemID      int
+	transactional bool
+	status        string
+	before        map[string]string
+	after         map[string]string
+	err           error
+}
+
+type DoPackageUpdate struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                dpu.InputData
+}
+
+func NewDoPackageUpdate(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input dpu.InputData) *DoPackageUpdate {
+	return &DoPackageUpdate{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// DoPackageUpdate upgrades the given packages on the given systems, or on all active systems in the given group.
+// Only systems with an installed package for which a newer version is installable are updated. The systems are
+// updated in parallel, in batches of the given size. Transactional systems are updated with transactional-update
+// and rebooted. The versions before and after the update are printed per system.
+// Returns an error when the update failed on one or more systems.
+func (h *DoPackageUpdate) DoPackageUpdate() error {
+	log.Debug("DoPackageUpdate started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systems, err := h.validateDoPackageUpdate(authParm)
+	if err != nil {
+		return err
+	}
+	results := h.doDoPackageUpdate(authParm, systems)
+	failed := h.printSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("package update failed on %v of %v systems", failed, len(results))
+	}
+	log.Info("DoPackageUpdate finished")
+	return nil
+}
+
+// validateDoPackageUpdate checks the parameters and returns the systems to be updated.
+func (h *DoPackageUpdate) validateDoPackageUpdate(authParm _sumanUseCase.AuthParams) ([]sumamodels.SystemGroupListSystemsMinimal, error) {
+	log.Debug("doPackageUpdate validateDoPackageUpdate started")
+	if len(h.input.Packages) == 0 {
+		return nil, fmt.Errorf("packages are mandatory")
+	}
+	if len(h.input.Group) == 0 && len(h.input.Systems) == 0 {
+		return nil, fmt.Errorf("group or systems is mandatory")
+	}
+	if len(h.input.Group) > 0 && len(h.input.Systems) > 0 {
+		return nil, fmt.Errorf("group and systems cannot be given both")
+	}
+	if h.input.BatchSize < 1 {
+		return nil, fmt.Errorf("batch size should be at least 1")
+	}
+	if len(h.input.Systems) > 0 {
+		var systems []sumamodels.SystemGroupListSystemsMinimal
+		for _, name := range h.input.Systems {
+			found, err := h.sumanProxy.SystemGetID(authParm, name)
+			if err != nil {
+				return nil, err
+			}
+			if len(found) == 0 {
+				return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, name)
+			}
+			if len(found) > 1 {
+				return nil, fmt.Errorf("more than one system found with name %v", name)
+			}
+			systems = append(systems, sumamodels.SystemGroupListSystemsMinimal{ID: found[0].ID, Name: found[0].Name})
+		}
+		return systems, nil
+	}
+	active, err := h.suse.ActiveGroupSystems(authParm, h.input.Group)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) == 0 {
+		return nil, fmt.Errorf("no active systems in group %v", h.input.Group)
+	}
+	log.Debug("doPackageUpdate validateDoPackageUpdate finished")
+	return active, nil
+}
+
+// doDoPackageUpdate updates the systems in batches. The systems in a batch are updated in parallel and the batch is
+// waited for before the next starts.
+func (h *DoPackageUpdate) doDoPackageUpdate(authParm _sumanUseCase.AuthParams, systems []sumamodels.SystemGroupListSystemsMinimal) []*systemResult {
+	log.Debug("doDoPackageUpdate started")
+	var results []*systemResult
+	for start := 0; start < len(systems); start += h.input.BatchSize {
+		end := start + h.input.BatchSize
+		if end > len(systems) {
+			end = len(systems)
+		}
+		if start > 0 {
+			err := _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*time.Duration(h.genConfig.Maintenance.WaitBetweenSystems))
+			if err != nil {
+				for _, system := range systems[start:] {
+					results = append(results, &systemResult{name: system.Name, systemID: system.ID, status: "not started", err: err})
+				}
+				break
+			}
+		}
+		log.Info(fmt.Sprintf("updating systems %v to %v of %v", start+1, end, len(systems)))
+		var wg sync.WaitGroup
+		for _, system := range systems[start:end] {
+			result := &systemResult{name: system.Name, systemID: system.ID}
+			results = append(results, result)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h.updateSystem(authParm, result)
+			}()
+		}
+		wg.Wait()
+	}
+	log.Debug("doDoPackageUpdate finished")
+	return results
+}
+
+// updateSystem determines the packages to be upgraded on the system, upgrades them and collects the new versions.
+func (h *DoPackageUpdate) updateSystem(authParm _sumanUseCase.AuthParams, result *systemResult) {
+	installed, err := h.sumanProxy.SystemListInstalledPackages(authParm, result.systemID)
+	if err != nil {
+		result.status, result.err = "failed", err
+		return
+	}
+	result.before = h.versions(installed)
+	if len(result.before) == 0 {
+		result.status = "not installed"
+		return
+	}
+	installable, err := h.sumanProxy.ListLatestInstallablePackages(authParm, result.systemID)
+	if err != nil {
+		result.status, result.err = "failed", err
+		return
+	}
+	var packageIDs []int
+	var packageNames []string
+	for _, pkg := range installable {
+		current, ok := result.before[pkg.Name]
+		if !ok || current == evr(pkg.Epoch, pkg.Version, pkg.Release) {
+			continue
+		}
+		packageIDs = append(packageIDs, pkg.ID)
+		packageNames = append(packageNames, pkg.Name)
+	}
+	if len(packageIDs) == 0 {
+		result.status = "up to date"
+		result.after = result.before
+		return
+	}
+	result.transactional, err = h.isTransactional(authParm, result.systemID)
+	if err != nil {
+		result.status, result.err = "failed", err
+		return
+	}
+	log.Info(fmt.Sprintf("upgrading %v on system %v", strings.Join(packageNames, ", "), result.name))
+	if result.transactional {
+		script := "#!/bin/bash\ntransactional-update -c -n pkg update " + strings.Join(packageNames, " ")
+		err = h.sumanProxy.ScheduleScriptRun(authParm, result.systemID, h.suseoperationtimeout, script)
+		if err == nil {
+			log.Info(fmt.Sprintf("rebooting transactional system %v", result.name))
+			err = h.sumanProxy.SystemScheduleReboot(authParm, result.systemID, h.suseoperationtimeout)
+		}
+	} else {
+		var actionID int
+		actionID, err = h.sumanProxy.SystemSchedulePackageInstall(authParm, result.systemID, packageIDs)
+		if err == nil {
+			_, err = h.sumanProxy.CheckProgress(authParm, actionID, h.suseoperationtimeout, "SystemSchedulePackageInstall", result.systemID)
+		}
+	}
+	if err != nil {
+		result.status, result.err = "failed", err
+		return
+	}
+	// the package list of the system is refreshed by the package action, or by the reboot for transactional systems
+	installed, err = h.sumanProxy.SystemListInstalledPackages(authParm, result.systemID)
+	if err != nil {
+		result.status, result.err = "failed", err
+		return
+	}
+	result.after = h.versions(installed)
+	result.status = "updated"
+}
+
+// versions returns the installed version of each of the requested packages.
+func (h *DoPackageUpdate) versions(installed []sumamodels.InstalledPackage) map[string]string {
+	versions := map[string]string{}
+	for _, pkg := range installed {
+		if util.Contains(h.input.Packages, pkg.Name) {
+			versions[pkg.Name] = evr(pkg.Epoch, pkg.Version, pkg.Release)
+		}
+	}
+	return versions
+}
+
+// isTransactional checks if the system is a transactional system, based on its base channel.
+func (h *DoPackageUpdate) isTransactional(authParm _sumanUseCase.AuthParams, systemID int) (bool, error) {
+	baseChannel, err := h.sumanProxy.SystemGetSubscribedBaseChannel(authParm, systemID)
+	if err != nil {
+		return false, err
+	}
+	for _, part := range transactionalChannels {
+		if strings.Contains(baseChannel.Label, part) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evr returns the version of a package as [epoch:]version-release.
+func evr(epoch string, version string, release string) string {
+	epoch = strings.TrimSpace(epoch)
+	if len(epoch) > 0 && epoch != "0" {
+		return fmt.Sprintf("%v:%v-%v", epoch, version, release)
+	}
+	return fmt.Sprintf("%v-%v", version, release)
+}
+
+// printSummary prints the versions before and after the update per system and returns the number of failed systems.
+func (h *DoPackageUpdate) printSummary(results []*systemResult) int {
+	failed := 0
+	for _, result := range results {
+		kind := ""
+		if result.transactional {
+			kind = " (transactional)"
+		}
+		fmt.Printf("%v%v: %v\n", result.name, kind, result.status)
+		var names []string
+		for name := range result.before {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("    %-40s %s -> %s\n", name, result.before[name], result.after[name])
+		}
+		if result.err != nil {
+			fmt.Printf("    error: %v\n", result.err)
+			failed++
+		}
+	}
+	return failed
+}
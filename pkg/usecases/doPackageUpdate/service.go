@@ -0,0 +1,5 @@
+package doPackageUpdate
+
+type IDoPackageUpdate interface {
+	DoPackageUpdate() error
+}
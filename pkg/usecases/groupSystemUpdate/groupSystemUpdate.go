@@ -0,0 +1,196 @@
+package groupSystemUpdate
+
+import (
+	"fmt"
+	gsu "mlmtool/pkg/models/groupSystemUpdate"
+	"mlmtool/pkg/models/inputfile"
+	"strings"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	"mlmtool/pkg/usecases/orchestrator"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	util "mlmtool/pkg/util/contains"
+	"mlmtool/pkg/util/errorhandling"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+type GroupSystemUpdate struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                gsu.InputData
+}
+
+func NewGroupSystemUpdate(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input gsu.InputData) *GroupSystemUpdate {
+	return &GroupSystemUpdate{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// GroupSystemUpdate updates all active systems in the given system group, maintenance.max_parallel systems at once.
+// Systems listed in maintenance.exclude_for_patch are skipped. Failures are handled according to
+// error_handling.update and error_handling.reboot. Returns an error when a fatal failure occurred
+// or when one or more systems failed to update, except for failures handled with the warning policy.
+func (h *GroupSystemUpdate) GroupSystemUpdate() error {
+	log.Debug("GroupSystemUpdate started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systems, err := h.validateGroupSystemUpdate(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doGroupSystemUpdate(authParm, systems)
+	if err != nil {
+		return err
+	}
+	log.Info("GroupSystemUpdate finished")
+	return nil
+}
+
+// validateGroupSystemUpdate checks the group exists and returns the active systems in the group.
+func (h *GroupSystemUpdate) validateGroupSystemUpdate(authParm _sumanUseCase.AuthParams) ([]sumamodels.SystemGroupListSystemsMinimal, error) {
+	log.Debug("groupSystemUpdate validateGroupSystemUpdate started")
+	if len(h.input.Group) == 0 {
+		return nil, fmt.Errorf("group is mandatory")
+	}
+	active, err := h.suse.ActiveGroupSystems(authParm, h.input.Group)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("groupSystemUpdate validateGroupSystemUpdate finished")
+	return active, nil
+}
+
+// doGroupSystemUpdate updates every system that is not excluded and prints a summary. At most
+// maintenance.max_parallel systems are updated at once, started maintenance.wait_between_systems seconds apart.
+func (h *GroupSystemUpdate) doGroupSystemUpdate(authParm _sumanUseCase.AuthParams, systems []sumamodels.SystemGroupListSystemsMinimal) error {
+	log.Debug("doGroupSystemUpdate started")
+	var skipped []string
+	var tasks []orchestrator.Task
+	for _, system := range systems {
+		if h.isExcluded(system.Name) {
+			log.Info(fmt.Sprintf("system %v is excluded for patching", system.Name))
+			skipped = append(skipped, system.Name)
+			continue
+		}
+		tasks = append(tasks, orchestrator.Task{SystemID: system.ID, Name: system.Name, Steps: h.updateSteps()})
+	}
+	runner := orchestrator.NewOrchestrator(h.sumanProxy, h.genConfig.Maintenance.MaxParallel, h.genConfig.Maintenance.WaitBetweenSystems, h.suseoperationtimeout)
+	var fatal error
+	runner.StopOnFailure = func(result *orchestrator.Result) bool {
+		if err := h.handle(result); err != nil && fatal == nil {
+			fatal = err
+		}
+		return fatal != nil
+	}
+	results := runner.Run(authParm, tasks)
+	var updated, warned, failed, interrupted []string
+	for _, result := range results {
+		switch {
+		case result.Status == orchestrator.StatusCompleted:
+			updated = append(updated, result.Name)
+		case result.Status == orchestrator.StatusSkipped:
+			skipped = append(skipped, result.Name)
+		case result.Status == orchestrator.StatusInterrupted:
+			interrupted = append(interrupted, result.Name)
+		case result.Policy == errorhandling.Warning:
+			warned = append(warned, result.Name)
+		default:
+			failed = append(failed, result.Name)
+		}
+	}
+	log.Info(fmt.Sprintf("updated: %v", strings.Join(updated, ", ")))
+	log.Info(fmt.Sprintf("skipped: %v", strings.Join(skipped, ", ")))
+	if len(warned) > 0 {
+		log.Warn(fmt.Sprintf("failed, ignored by error_handling: %v", strings.Join(warned, ", ")))
+	}
+	if fatal != nil {
+		return fatal
+	}
+	if err := h.sumanProxy.Context().Err(); err != nil {
+		log.Warn(fmt.Sprintf("interrupted, still in progress on the server: %v", strings.Join(interrupted, ", ")))
+		return fmt.Errorf("update of group %v interrupted: %w", h.input.Group, err)
+	}
+	if len(failed) > 0 {
+		log.Error(fmt.Sprintf("failed: %v", strings.Join(failed, ", ")))
+		return fmt.Errorf("update failed for %v of %v systems in group %v", len(failed), len(systems), h.input.Group)
+	}
+	log.Debug("doGroupSystemUpdate finished")
+	return nil
+}
+
+// updateSteps returns the steps to update a system: apply the relevant errata, upgrade the remaining packages and
+// optionally reboot.
+func (h *GroupSystemUpdate) updateSteps() []orchestrator.Step {
+	steps := []orchestrator.Step{
+		{Name: "update", Schedule: h.scheduleErrata},
+		{Name: "update", Schedule: h.schedulePackages},
+	}
+	if h.input.Reboot {
+		steps = append(steps, orchestrator.Step{Name: "reboot", Schedule: func(auth _sumanUseCase.AuthParams, systemID int) ([]int, error) {
+			actionID, err := h.sumanProxy.SystemScheduleRebootAction(auth, systemID)
+			return []int{actionID}, err
+		}})
+	}
+	return steps
+}
+
+// scheduleErrata schedules all relevant errata on the system.
+func (h *GroupSystemUpdate) scheduleErrata(authParm _sumanUseCase.AuthParams, systemID int) ([]int, error) {
+	errata, err := h.sumanProxy.SystemGetRelevantErrata(authParm, systemID)
+	if err != nil || len(errata) == 0 {
+		return nil, err
+	}
+	var errataIDs []int
+	for i := range errata {
+		errataIDs = append(errataIDs, errata[i].ID)
+	}
+	return h.sumanProxy.SystemScheduleApplyErrata(authParm, systemID, errataIDs)
+}
+
+// schedulePackages schedules the upgrade of all remaining upgradable packages on the system.
+func (h *GroupSystemUpdate) schedulePackages(authParm _sumanUseCase.AuthParams, systemID int) ([]int, error) {
+	pkgs, err := h.sumanProxy.SystemListLatestUpgradablePackages(authParm, systemID)
+	if err != nil || len(pkgs) == 0 {
+		return nil, err
+	}
+	var packageIDs []int
+	for i := range pkgs {
+		packageIDs = append(packageIDs, pkgs[i].ToPackageID)
+	}
+	actionID, err := h.sumanProxy.SystemSchedulePackageInstall(authParm, systemID, packageIDs)
+	if err != nil {
+		return nil, err
+	}
+	return []int{actionID}, nil
+}
+
+// handle applies error_handling.update or error_handling.reboot to the failed system, or error_handling.timeout_passed
+// when the action ran into a timeout, and records the applied policy on the result. Returns the error when the
+// failure is fatal.
+func (h *GroupSystemUpdate) handle(result *orchestrator.Result) error {
+	policy := h.genConfig.ErrorHandling.Update
+	if result.Step == "reboot" {
+		policy = h.genConfig.ErrorHandling.Reboot
+	}
+	result.Policy = errorhandling.ActionPolicy(h.genConfig.ErrorHandling, policy, result.Err)
+	return errorhandling.HandleAction(h.genConfig.ErrorHandling, policy, fmt.Sprintf("%v of system %v", result.Step, result.Name), result.Err)
+}
+
+// isExcluded checks if the system is listed in maintenance.exclude_for_patch, either by full or short hostname.
+func (h *GroupSystemUpdate) isExcluded(name string) bool {
+	shortName := strings.Split(name, ".")[0]
+	return util.Contains(h.genConfig.Maintenance.ExcludeForPatch, name) || util.Contains(h.genConfig.Maintenance.ExcludeForPatch, shortName)
+}
@@ -0,0 +1,159 @@
+package groupSystemUpdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	gsu "mlmtool/pkg/models/groupSystemUpdate"
+	"mlmtool/pkg/models/inputfile"
+	sumamodels "mlmtool/pkg/models/susemanager"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	"mlmtool/pkg/usecases/orchestrator"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	orchestrator.PollInterval = 10 * time.Millisecond
+	testutil.Main(m)
+}
+
+func TestGroupSystemUpdate(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           gsu.InputData
+		actionState     string
+		timeout         int
+		maintenance     inputfile.Maintenance
+		errorHandling   inputfile.ErrorHandling
+		setup           func(fake *fakesuma.Server, ids []int)
+		expectError     bool
+		expectedErr     string
+		expectedActions map[string]int
+	}{
+		{
+			name:            "active systems updated and rebooted",
+			input:           gsu.InputData{Group: "web", Reboot: true},
+			maintenance:     inputfile.Maintenance{MaxParallel: 2},
+			expectedActions: map[string]int{"web01.example.com": 3, "web02.example.com": 3},
+		},
+		{
+			name:            "excluded first system skipped without waiting",
+			input:           gsu.InputData{Group: "web"},
+			maintenance:     inputfile.Maintenance{MaxParallel: 1, WaitBetweenSystems: 60, ExcludeForPatch: []string{"web01"}},
+			expectedActions: map[string]int{"web02.example.com": 2},
+		},
+		{
+			name:            "failed update continues with error policy",
+			input:           gsu.InputData{Group: "web"},
+			actionState:     fakesuma.StateFailed,
+			maintenance:     inputfile.Maintenance{MaxParallel: 1},
+			errorHandling:   inputfile.ErrorHandling{Update: "error"},
+			expectError:     true,
+			expectedActions: map[string]int{"web01.example.com": 1, "web02.example.com": 1},
+		},
+		{
+			name:            "failed update ignored with warning policy",
+			input:           gsu.InputData{Group: "web"},
+			actionState:     fakesuma.StateFailed,
+			maintenance:     inputfile.Maintenance{MaxParallel: 2},
+			errorHandling:   inputfile.ErrorHandling{Update: "warning"},
+			expectedActions: map[string]int{"web01.example.com": 1, "web02.example.com": 1},
+		},
+		{
+			name:          "fatal failure kept when a parallel system fails later",
+			input:         gsu.InputData{Group: "web"},
+			actionState:   fakesuma.StateInProgress,
+			timeout:       1,
+			maintenance:   inputfile.Maintenance{MaxParallel: 2},
+			errorHandling: inputfile.ErrorHandling{Update: "fatal", TimeoutPassed: "warning"},
+			setup: func(fake *fakesuma.Server, ids []int) {
+				system, _ := fake.GetSystem(ids[0])
+				system.Errata = nil
+				fake.AddSystem(system)
+				fake.Fail("system/scheduleApplyErrata", "errata not applicable", 0)
+			},
+			expectError:     true,
+			expectedErr:     "update of system web02.example.com failed",
+			expectedActions: map[string]int{"web01.example.com": 1},
+		},
+		{
+			name:            "failed update stops with fatal policy",
+			input:           gsu.InputData{Group: "web"},
+			actionState:     fakesuma.StateFailed,
+			maintenance:     inputfile.Maintenance{MaxParallel: 1},
+			errorHandling:   inputfile.ErrorHandling{Update: "fatal"},
+			expectError:     true,
+			expectedActions: map[string]int{"web01.example.com": 1},
+		},
+		{
+			name:            "timeout handled by timeout_passed policy",
+			input:           gsu.InputData{Group: "web"},
+			actionState:     fakesuma.StateInProgress,
+			timeout:         1,
+			maintenance:     inputfile.Maintenance{MaxParallel: 1},
+			errorHandling:   inputfile.ErrorHandling{Update: "fatal", TimeoutPassed: "warning"},
+			expectedActions: map[string]int{"web01.example.com": 1, "web02.example.com": 1},
+		},
+		{
+			name:            "unknown group",
+			input:           gsu.InputData{Group: "db"},
+			maintenance:     inputfile.Maintenance{MaxParallel: 1},
+			expectError:     true,
+			expectedActions: map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			if len(tt.actionState) > 0 {
+				fake.ActionState = tt.actionState
+			}
+			var ids []int
+			for _, name := range []string{"web01.example.com", "web02.example.com", "web03.example.com"} {
+				ids = append(ids, fake.AddSystem(fakesuma.System{
+					Name:       name,
+					Inactive:   name == "web03.example.com",
+					Errata:     []sumamodels.Errata{{ID: 1, AdvisoryName: "SUSE-SU-2026:1234-1"}},
+					Upgradable: []sumamodels.UpgradablePackage{{Name: "openssl", ToVersion: "3.1.4", ToPackageID: 2}},
+				}))
+			}
+			fake.AddGroup("web", ids...)
+			if tt.setup != nil {
+				tt.setup(fake, ids)
+			}
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			genConfig.Maintenance = tt.maintenance
+			genConfig.ErrorHandling = tt.errorHandling
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 60
+			}
+			proxy := fake.Proxy()
+			h := NewGroupSystemUpdate(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), timeout, genConfig, tt.input)
+
+			start := time.Now()
+			err := h.GroupSystemUpdate()
+			if tt.expectError {
+				assert.Error(t, err)
+				if len(tt.expectedErr) > 0 {
+					assert.ErrorContains(t, err, tt.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Less(t, time.Since(start), 30*time.Second)
+			actions := make(map[string]int)
+			for _, action := range fake.Actions(0) {
+				system, _ := fake.GetSystem(action.SystemID)
+				actions[system.Name]++
+			}
+			assert.Equal(t, tt.expectedActions, actions)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package groupSystemUpdate
+
+type IGroupSystemUpdate interface {
+	GroupSystemUpdate() error
+}
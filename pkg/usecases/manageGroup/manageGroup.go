@@ -0,0 +1,387 @@
+package manageGroup
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	mg "mlmtool/pkg/models/manageGroup"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ActionCreate creates a system group
+	ActionCreate = "create"
+	// ActionDelete deletes a system group
+	ActionDelete = "delete"
+	// ActionAdd adds systems to a system group
+	ActionAdd = "add"
+	// ActionRemove removes systems from a system group
+	ActionRemove = "remove"
+	// ActionList lists the system groups or the systems in a system group
+	ActionList = "list"
+	// ActionSync makes the system groups match the given file
+	ActionSync = "sync"
+)
+
+type ManageGroup struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                mg.InputData
+}
+
+func NewManageGroup(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input mg.InputData) *ManageGroup {
+	return &ManageGroup{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// ManageGroup performs the requested action on the system groups: create or delete a group, add or remove
+// systems by name or regular expression, list the membership, or sync the membership from a YAML file.
+func (h *ManageGroup) ManageGroup() error {
+	log.Debug("ManageGroup started")
+	err := h.validateManageGroup()
+	if err != nil {
+		return err
+	}
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	switch h.input.Action {
+	case ActionCreate:
+		err = h.createGroup(authParm)
+	case ActionDelete:
+		err = h.deleteGroup(authParm)
+	case ActionAdd:
+		err = h.addSystems(authParm)
+	case ActionRemove:
+		err = h.removeSystems(authParm)
+	case ActionList:
+		err = h.listGroups(authParm)
+	case ActionSync:
+		err = h.syncGroups(authParm)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("ManageGroup finished")
+	return nil
+}
+
+// validateManageGroup checks the parameters needed for the requested action are given.
+func (h *ManageGroup) validateManageGroup() error {
+	log.Debug("manageGroup validateManageGroup started")
+	switch h.input.Action {
+	case ActionCreate, ActionDelete:
+		if len(h.input.Group) == 0 {
+			return fmt.Errorf("group is mandatory")
+		}
+	case ActionAdd, ActionRemove:
+		if len(h.input.Group) == 0 {
+			return fmt.Errorf("group is mandatory")
+		}
+		if len(h.input.Systems) == 0 && len(h.input.Regex) == 0 {
+			return fmt.Errorf("systems or regex is mandatory")
+		}
+		if len(h.input.Regex) > 0 {
+			_, err := regexp.Compile(h.input.Regex)
+			if err != nil {
+				return fmt.Errorf("invalid regex %v: %v", h.input.Regex, err)
+			}
+		}
+	case ActionList:
+	case ActionSync:
+		if len(h.input.File) == 0 {
+			return fmt.Errorf("file is mandatory")
+		}
+	default:
+		return fmt.Errorf("unknown action %v", h.input.Action)
+	}
+	log.Debug("manageGroup validateManageGroup finished")
+	return nil
+}
+
+// createGroup creates the given system group.
+func (h *ManageGroup) createGroup(authParm _sumanUseCase.AuthParams) error {
+	_, err := h.sumanProxy.SystemGroupGetDetails(authParm, h.input.Group)
+	if err == nil {
+		return fmt.Errorf("system group %v already exists", h.input.Group)
+	}
+	description := h.input.Description
+	if len(description) == 0 {
+		description = h.input.Group
+	}
+	_, err = h.sumanProxy.SystemGroupCreate(authParm, h.input.Group, description)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("system group %v created", h.input.Group))
+	return nil
+}
+
+// deleteGroup deletes the given system group. The systems in the group are not touched.
+func (h *ManageGroup) deleteGroup(authParm _sumanUseCase.AuthParams) error {
+	_, err := h.sumanProxy.SystemGroupGetDetails(authParm, h.input.Group)
+	if err != nil {
+		return fmt.Errorf("%v: %v", returnCodes.ErrSystemGroupNotFound, h.input.Group)
+	}
+	err = h.sumanProxy.SystemGroupDelete(authParm, h.input.Group)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("system group %v deleted", h.input.Group))
+	return nil
+}
+
+// addSystems adds the given systems, or the systems matching the regex, to the system group.
+func (h *ManageGroup) addSystems(authParm _sumanUseCase.AuthParams) error {
+	_, err := h.sumanProxy.SystemGroupGetDetails(authParm, h.input.Group)
+	if err != nil {
+		return fmt.Errorf("%v: %v", returnCodes.ErrSystemGroupNotFound, h.input.Group)
+	}
+	systems, err := h.sumanProxy.SystemListSystems(authParm)
+	if err != nil {
+		return err
+	}
+	selected, err := selectSystems(systems, h.input.Systems, h.input.Regex)
+	if err != nil {
+		return err
+	}
+	members, err := h.members(authParm, h.input.Group)
+	if err != nil {
+		return err
+	}
+	var toAdd []sumamodels.System
+	for _, system := range selected {
+		if _, ok := members[system.ID]; !ok {
+			toAdd = append(toAdd, system)
+		}
+	}
+	return h.changeMembership(authParm, h.input.Group, toAdd, true)
+}
+
+// removeSystems removes the given systems, or the systems matching the regex, from the system group.
+func (h *ManageGroup) removeSystems(authParm _sumanUseCase.AuthParams) error {
+	members, err := h.members(authParm, h.input.Group)
+	if err != nil {
+		return err
+	}
+	var systems []sumamodels.System
+	for _, member := range members {
+		systems = append(systems, member)
+	}
+	toRemove, err := selectSystems(systems, h.input.Systems, h.input.Regex)
+	if err != nil {
+		return err
+	}
+	return h.changeMembership(authParm, h.input.Group, toRemove, false)
+}
+
+// listGroups prints the systems in the given system group, or all system groups when no group is given.
+func (h *ManageGroup) listGroups(authParm _sumanUseCase.AuthParams) error {
+	if len(h.input.Group) == 0 {
+		groups, err := h.sumanProxy.SystemGroupListAllGroups(authParm)
+		if err != nil {
+			return err
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+		fmt.Printf("%-40s %-8s %s\n", "group", "systems", "description")
+		for _, group := range groups {
+			fmt.Printf("%-40s %-8d %s\n", group.Name, group.SystemCount, group.Description)
+		}
+		return nil
+	}
+	members, err := h.members(authParm, h.input.Group)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedNames(members) {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// syncGroups makes the membership of the system groups in the given file match the file. Missing groups are
+// created, missing systems are added and systems not listed are removed. Groups not in the file are left alone.
+func (h *ManageGroup) syncGroups(authParm _sumanUseCase.AuthParams) error {
+	data, err := os.ReadFile(filepath.Clean(h.input.File))
+	if err != nil {
+		return fmt.Errorf("unable to read %v: %v", h.input.File, err)
+	}
+	var groupsFile mg.GroupsFile
+	err = yaml.Unmarshal(data, &groupsFile)
+	if err != nil {
+		return fmt.Errorf("unable to parse %v: %v", h.input.File, err)
+	}
+	systems, err := h.sumanProxy.SystemListSystems(authParm)
+	if err != nil {
+		return err
+	}
+	var groupNames []string
+	for name := range groupsFile.Groups {
+		groupNames = append(groupNames, name)
+	}
+	sort.Strings(groupNames)
+	failed := 0
+	for _, name := range groupNames {
+		err = h.syncGroup(authParm, name, groupsFile.Groups[name], systems)
+		if err != nil {
+			log.Error(fmt.Sprintf("sync of system group %v failed: %v", name, err))
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("sync failed for %v of %v system groups", failed, len(groupNames))
+	}
+	return nil
+}
+
+// syncGroup makes the membership of a single system group match its definition.
+func (h *ManageGroup) syncGroup(authParm _sumanUseCase.AuthParams, name string, definition mg.GroupDefinition, systems []sumamodels.System) error {
+	desired, err := selectSystems(systems, definition.Systems, "")
+	if err != nil {
+		return err
+	}
+	members := map[int]sumamodels.System{}
+	_, err = h.sumanProxy.SystemGroupGetDetails(authParm, name)
+	if err != nil {
+		log.Info(fmt.Sprintf("creating system group %v", name))
+		if !h.input.DryRun {
+			description := definition.Description
+			if len(description) == 0 {
+				description = name
+			}
+			_, err = h.sumanProxy.SystemGroupCreate(authParm, name, description)
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		members, err = h.members(authParm, name)
+		if err != nil {
+			return err
+		}
+	}
+	desiredIDs := map[int]bool{}
+	var toAdd, toRemove []sumamodels.System
+	for _, system := range desired {
+		desiredIDs[system.ID] = true
+		if _, ok := members[system.ID]; !ok {
+			toAdd = append(toAdd, system)
+		}
+	}
+	for _, member := range members {
+		if !desiredIDs[member.ID] {
+			toRemove = append(toRemove, member)
+		}
+	}
+	err = h.changeMembership(authParm, name, toAdd, true)
+	if err != nil {
+		return err
+	}
+	return h.changeMembership(authParm, name, toRemove, false)
+}
+
+// members returns the systems in the given system group by their id.
+func (h *ManageGroup) members(authParm _sumanUseCase.AuthParams, group string) (map[int]sumamodels.System, error) {
+	systems, err := h.sumanProxy.SystemGroupListSystemsMinimal(authParm, group)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemGroupNotFound, group)
+	}
+	members := map[int]sumamodels.System{}
+	for _, system := range systems {
+		members[system.ID] = sumamodels.System{ID: system.ID, Name: system.Name}
+	}
+	return members, nil
+}
+
+// changeMembership adds the systems to or removes them from the system group. Nothing is changed on a dry run.
+func (h *ManageGroup) changeMembership(authParm _sumanUseCase.AuthParams, group string, systems []sumamodels.System, add bool) error {
+	if len(systems) == 0 {
+		return nil
+	}
+	action := "removing"
+	if add {
+		action = "adding"
+	}
+	var ids []int
+	var names []string
+	for _, system := range systems {
+		ids = append(ids, system.ID)
+		names = append(names, system.Name)
+	}
+	sort.Strings(names)
+	log.Info(fmt.Sprintf("system group %v: %v %v", group, action, strings.Join(names, ", ")))
+	if h.input.DryRun {
+		return nil
+	}
+	return h.sumanProxy.SystemGroupAddOrRemoveSystems(authParm, group, ids, add)
+}
+
+// selectSystems returns the systems matching one of the given names, by full or short hostname, or the regex.
+// Returns an error when a given name does not match any system.
+func selectSystems(systems []sumamodels.System, names []string, regex string) ([]sumamodels.System, error) {
+	var pattern *regexp.Regexp
+	if len(regex) > 0 {
+		var err error
+		pattern, err = regexp.Compile(regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %v: %v", regex, err)
+		}
+	}
+	found := map[string]bool{}
+	var selected []sumamodels.System
+	for _, system := range systems {
+		shortName := strings.Split(system.Name, ".")[0]
+		matched := false
+		for _, name := range names {
+			if name == system.Name || name == shortName {
+				found[name] = true
+				matched = true
+			}
+		}
+		if matched || (pattern != nil && pattern.MatchString(system.Name)) {
+			selected = append(selected, system)
+		}
+	}
+	var unknown []string
+	for _, name := range names {
+		if !found[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// sortedNames returns the names of the given systems in alphabetical order.
+func sortedNames(systems map[int]sumamodels.System) []string {
+	var names []string
+	for _, system := range systems {
+		names = append(names, system.Name)
+	}
+	sort.Strings(names)
+	return names
+}
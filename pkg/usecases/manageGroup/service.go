@@ -0,0 +1,5 @@
+package manageGroup
+
+type IManageGroup interface {
+	ManageGroup() error
+}
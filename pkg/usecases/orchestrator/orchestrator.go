@@ -0,0 +1,307 @@
+// Package orchestrator runs scheduled actions on many systems at once. The actions of all systems are tracked
+// together in a single poll loop, with a maximum number of systems in progress and a minimum time between
+// starting systems.
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+)
+
+const (
+	// StatusPending - the task is not started yet
+	StatusPending = "pending"
+	// StatusRunning - the actions of the task are scheduled and not finished yet
+	StatusRunning = "running"
+	// StatusCompleted - all steps of the task completed
+	StatusCompleted = "completed"
+	// StatusFailed - a step of the task failed
+	StatusFailed = "failed"
+	// StatusTimeout - a step of the task didn't finish within the timeout
+	StatusTimeout = "timeout"
+	// StatusSkipped - the task was not started, as the run was stopped
+	StatusSkipped = "skipped"
+	// StatusInterrupted - the run was interrupted while the actions of the task were still scheduled
+	StatusInterrupted = "interrupted"
+)
+
+// PollInterval is the time between two polls of the actions in progress, used by the orchestrators created
+// afterwards
+var PollInterval = 15 * time.Second
+
+// maxPollErrors is the number of polls in a row the actions in progress may fail to be listed before the running
+// tasks are given up as failed
+const maxPollErrors = 10
+
+// Step - a step of a task. Schedule schedules the actions of the step on the system and returns their ids. A step
+// without actions is completed directly.
+type Step struct {
+	Name     string
+	Schedule func(auth _sumanUseCase.AuthParams, systemID int) ([]int, error)
+}
+
+// Task - the steps to be run, one after another, on a single system
+type Task struct {
+	SystemID int
+	Name     string
+	Steps    []Step
+}
+
+// Result - outcome of a task. Step is the step running when the task failed or timed out.
+type Result struct {
+	SystemID  int
+	Name      string
+	Status    string
+	Step      string
+	ActionIDs []int
+	Err       error
+	Started   time.Time
+	Finished  time.Time
+	// Policy - error_handling policy applied to the failure, to be set by StopOnFailure
+	Policy string
+}
+
+// run - state of a started task
+type run struct {
+	task      Task
+	result    *Result
+	step      int
+	actionIDs []int
+	scheduled time.Time
+}
+
+// Orchestrator runs tasks on many systems in parallel. The actions scheduled by the steps of all running tasks are
+// polled together with one call listing the actions in progress.
+type Orchestrator struct {
+	sumanProxy   _sumanUseCase.IProxy
+	maxParallel  int
+	waitBetween  time.Duration
+	timeout      time.Duration
+	pollInterval time.Duration
+	// StopOnFailure is called for every failed or timed out task. When it returns true, no more tasks are started,
+	// the running tasks are finished and the tasks not started are skipped.
+	StopOnFailure func(result *Result) bool
+}
+
+// NewOrchestrator returns an orchestrator running at most maxParallel tasks at once, starting a task at least
+// waitBetweenSystems seconds after the previous one. Each step of a task has to finish within timeout seconds.
+func NewOrchestrator(sumanProxy _sumanUseCase.IProxy, maxParallel int, waitBetweenSystems int, timeout int) *Orchestrator {
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+	return &Orchestrator{
+		sumanProxy:   sumanProxy,
+		maxParallel:  maxParallel,
+		waitBetween:  time.Second * time.Duration(waitBetweenSystems),
+		timeout:      time.Second * time.Duration(timeout),
+		pollInterval: PollInterval,
+	}
+}
+
+// Run runs the tasks and returns their results, in the order of the tasks. When the context of the proxy is
+// cancelled, no more tasks are started and the running tasks are left to the server as interrupted.
+func (o *Orchestrator) Run(auth _sumanUseCase.AuthParams, tasks []Task) []*Result {
+	log.Debug(fmt.Sprintf("orchestrator started for %v tasks, %v in parallel", len(tasks), o.maxParallel))
+	results := make([]*Result, len(tasks))
+	for i, task := range tasks {
+		results[i] = &Result{SystemID: task.SystemID, Name: task.Name, Status: StatusPending}
+	}
+	var running []*run
+	next := 0
+	stopped := false
+	var lastStart time.Time
+	pollErrors := 0
+	for {
+		for !stopped && next < len(tasks) && len(running) < o.maxParallel && time.Since(lastStart) >= o.waitBetween {
+			r := &run{task: tasks[next], result: results[next]}
+			next++
+			lastStart = time.Now()
+			r.result.Started = lastStart
+			r.result.Status = StatusRunning
+			log.Info(fmt.Sprintf("starting %v", r.task.Name))
+			if o.advance(auth, r) {
+				running = append(running, r)
+			} else {
+				stopped = o.finished(r) || stopped
+			}
+		}
+		if len(running) == 0 && (stopped || next >= len(tasks)) {
+			break
+		}
+		wait := o.pollInterval
+		if len(running) == 0 {
+			wait = o.waitBetween - time.Since(lastStart)
+		}
+		if err := _sumanUseCase.Sleep(o.sumanProxy.Context(), wait); err != nil {
+			for _, r := range running {
+				r.result.Status = StatusInterrupted
+				r.result.Err = fmt.Errorf("actions %v of step %v are still scheduled: %w", r.actionIDs, r.result.Step, err)
+				r.result.Finished = time.Now()
+				log.Warn(fmt.Sprintf("%v interrupted in step %v, actions %v are still scheduled", r.task.Name, r.result.Step, r.actionIDs))
+			}
+			break
+		}
+		if len(running) == 0 {
+			continue
+		}
+		inProgress, err := o.inProgress(auth)
+		if err != nil {
+			pollErrors++
+			log.Warn(fmt.Sprintf("unable to list the actions in progress: %v", err))
+		} else {
+			pollErrors = 0
+		}
+		var stillRunning []*run
+		for _, r := range running {
+			if err != nil {
+				if !o.unknown(r, err, pollErrors) {
+					stopped = o.finished(r) || stopped
+					continue
+				}
+				stillRunning = append(stillRunning, r)
+			} else if o.poll(auth, r, inProgress) {
+				stillRunning = append(stillRunning, r)
+			} else {
+				stopped = o.finished(r) || stopped
+			}
+		}
+		running = stillRunning
+	}
+	for _, result := range results[next:] {
+		result.Status = StatusSkipped
+	}
+	log.Debug("orchestrator finished")
+	return results
+}
+
+// advance schedules the next step of the task with actions. Steps without actions are completed directly.
+// Returns true when the task has actions running.
+func (o *Orchestrator) advance(auth _sumanUseCase.AuthParams, r *run) bool {
+	for r.step < len(r.task.Steps) {
+		step := r.task.Steps[r.step]
+		r.result.Step = step.Name
+		actionIDs, err := step.Schedule(auth, r.task.SystemID)
+		if err != nil {
+			r.result.Status = StatusFailed
+			r.result.Err = err
+			return false
+		}
+		if len(actionIDs) > 0 {
+			log.Info(fmt.Sprintf("%v: %v scheduled, actions %v", r.task.Name, step.Name, actionIDs))
+			r.actionIDs = actionIDs
+			r.scheduled = time.Now()
+			r.result.ActionIDs = append(r.result.ActionIDs, actionIDs...)
+			return true
+		}
+		r.step++
+	}
+	r.result.Status = StatusCompleted
+	r.result.Step = ""
+	return false
+}
+
+// poll checks the actions of the current step. When they finished, the result on the system is checked and the
+// next step is scheduled. Returns true when the task has actions running.
+func (o *Orchestrator) poll(auth _sumanUseCase.AuthParams, r *run, inProgress map[int]bool) bool {
+	for _, actionID := range r.actionIDs {
+		if inProgress[actionID] {
+			return !o.timedOut(r)
+		}
+	}
+	for _, actionID := range r.actionIDs {
+		err := o.checkFailed(auth, actionID, r.task.SystemID)
+		if err != nil {
+			r.result.Status = StatusFailed
+			r.result.Err = err
+			return false
+		}
+	}
+	log.Info(fmt.Sprintf("%v: %v completed", r.task.Name, r.result.Step))
+	r.step++
+	r.actionIDs = nil
+	return o.advance(auth, r)
+}
+
+// unknown handles a task whose actions could not be polled, as listing the actions in progress failed pollErrors
+// times in a row. The task still times out, and fails once maxPollErrors is reached. Returns true when the task is
+// still considered running.
+func (o *Orchestrator) unknown(r *run, err error, pollErrors int) bool {
+	if o.timedOut(r) {
+		return false
+	}
+	if pollErrors >= maxPollErrors {
+		r.result.Status = StatusFailed
+		r.result.Err = fmt.Errorf("unable to list the actions in progress %v times: %w", pollErrors, err)
+		return false
+	}
+	return true
+}
+
+// timedOut marks the task as timed out when its current step is running longer than the timeout.
+func (o *Orchestrator) timedOut(r *run) bool {
+	if o.timeout > 0 && time.Since(r.scheduled) > o.timeout {
+		r.result.Status = StatusTimeout
+		r.result.Err = fmt.Errorf("action: %v %w", r.result.Step, _sumanUseCase.ErrActionTimeout)
+		return true
+	}
+	return false
+}
+
+// checkFailed returns an error wrapping ErrActionFailed when the action failed on the system.
+func (o *Orchestrator) checkFailed(auth _sumanUseCase.AuthParams, actionID int, systemID int) error {
+	failedSystems, err := o.sumanProxy.ScheduleListFailedSystems(auth, actionID)
+	if err != nil {
+		return err
+	}
+	for _, failed := range failedSystems {
+		if failed.ServerID == systemID {
+			return fmt.Errorf("action %v failed: %v: %w", actionID, strings.TrimSpace(failed.Message), _sumanUseCase.ErrActionFailed)
+		}
+	}
+	return nil
+}
+
+// inProgress returns the ids of all actions in progress on one or more systems.
+func (o *Orchestrator) inProgress(auth _sumanUseCase.AuthParams) (map[int]bool, error) {
+	actions, err := o.sumanProxy.ScheduleListInProgressActions(auth)
+	if err != nil {
+		return nil, err
+	}
+	inProgress := map[int]bool{}
+	for _, action := range actions {
+		inProgress[action.ID] = true
+	}
+	return inProgress, nil
+}
+
+// finished records the end of the task and returns true when the run should stop.
+func (o *Orchestrator) finished(r *run) bool {
+	r.result.Finished = time.Now()
+	if r.result.Status == StatusCompleted {
+		log.Info(fmt.Sprintf("%v completed", r.task.Name))
+		return false
+	}
+	log.Error(fmt.Sprintf("%v %v in step %v: %v", r.task.Name, r.result.Status, r.result.Step, r.result.Err))
+	return o.StopOnFailure != nil && o.StopOnFailure(r.result)
+}
+
+// Failed returns the results of the tasks that failed or timed out.
+func Failed(results []*Result) []*Result {
+	var failed []*Result
+	for _, result := range results {
+		if result.Status == StatusFailed || result.Status == StatusTimeout {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// IsTimeout reports whether the result failed because the timeout passed.
+func IsTimeout(result *Result) bool {
+	return errors.Is(result.Err, _sumanUseCase.ErrActionTimeout)
+}
@@ -0,0 +1,146 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	PollInterval = 10 * time.Millisecond
+	testutil.Main(m)
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		actionState    string
+		maxParallel    int
+		timeout        time.Duration
+		failPath       string
+		stopOnFailure  bool
+		cancel         bool
+		expectedStatus []string
+		expectedErr    error
+	}{
+		{
+			name:           "all tasks completed",
+			actionState:    fakesuma.StateCompleted,
+			maxParallel:    2,
+			expectedStatus: []string{StatusCompleted, StatusCompleted, StatusCompleted},
+		},
+		{
+			name:           "failed tasks do not stop the others",
+			actionState:    fakesuma.StateFailed,
+			maxParallel:    2,
+			expectedStatus: []string{StatusFailed, StatusFailed, StatusFailed},
+			expectedErr:    _sumanUseCase.ErrActionFailed,
+		},
+		{
+			name:           "failed task stops the run",
+			actionState:    fakesuma.StateFailed,
+			maxParallel:    1,
+			stopOnFailure:  true,
+			expectedStatus: []string{StatusFailed, StatusSkipped, StatusSkipped},
+			expectedErr:    _sumanUseCase.ErrActionFailed,
+		},
+		{
+			name:           "action in progress beyond the timeout",
+			actionState:    fakesuma.StateInProgress,
+			maxParallel:    3,
+			timeout:        50 * time.Millisecond,
+			expectedStatus: []string{StatusTimeout, StatusTimeout, StatusTimeout},
+			expectedErr:    _sumanUseCase.ErrActionTimeout,
+		},
+		{
+			name:           "actions in progress not listed until the timeout",
+			actionState:    fakesuma.StateInProgress,
+			maxParallel:    3,
+			timeout:        50 * time.Millisecond,
+			failPath:       "schedule/listInProgressActions",
+			expectedStatus: []string{StatusTimeout, StatusTimeout, StatusTimeout},
+			expectedErr:    _sumanUseCase.ErrActionTimeout,
+		},
+		{
+			name:           "actions in progress never listed without timeout",
+			actionState:    fakesuma.StateCompleted,
+			maxParallel:    3,
+			failPath:       "schedule/listInProgressActions",
+			expectedStatus: []string{StatusFailed, StatusFailed, StatusFailed},
+		},
+		{
+			name:           "scheduling fails",
+			actionState:    fakesuma.StateCompleted,
+			maxParallel:    1,
+			failPath:       "system/scheduleReboot",
+			expectedStatus: []string{StatusFailed, StatusFailed, StatusFailed},
+		},
+		{
+			name:           "interrupted while actions in progress",
+			actionState:    fakesuma.StateInProgress,
+			maxParallel:    1,
+			cancel:         true,
+			expectedStatus: []string{StatusInterrupted, StatusSkipped, StatusSkipped},
+			expectedErr:    context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.ActionState = tt.actionState
+			if len(tt.failPath) > 0 {
+				fake.Fail(tt.failPath, "injected failure", 0)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			proxy := fake.ProxyContext(ctx)
+			sessionKey, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			auth := _sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: sessionKey}
+			steps := []Step{
+				{Name: "nothing to do", Schedule: func(_ _sumanUseCase.AuthParams, _ int) ([]int, error) {
+					return nil, nil
+				}},
+				{Name: "reboot", Schedule: func(auth _sumanUseCase.AuthParams, systemID int) ([]int, error) {
+					actionID, err := proxy.SystemScheduleRebootAction(auth, systemID)
+					if tt.cancel {
+						cancel()
+					}
+					return []int{actionID}, err
+				}},
+			}
+			var tasks []Task
+			for _, name := range []string{"web01.example.com", "web02.example.com", "web03.example.com"} {
+				tasks = append(tasks, Task{SystemID: fake.AddSystem(fakesuma.System{Name: name}), Name: name, Steps: steps})
+			}
+			o := NewOrchestrator(proxy, tt.maxParallel, 0, 0)
+			o.timeout = tt.timeout
+			o.StopOnFailure = func(_ *Result) bool { return tt.stopOnFailure }
+
+			results := o.Run(auth, tasks)
+			var status []string
+			for _, result := range results {
+				status = append(status, result.Status)
+			}
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, results[0].Err, tt.expectedErr)
+			}
+			for _, result := range results {
+				if result.Status == StatusCompleted || result.Status == StatusSkipped {
+					assert.NoError(t, result.Err)
+				} else {
+					assert.Error(t, result.Err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package orchestrator
+
+import (
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+type IOrchestrator interface {
+	Run(auth _sumanUseCase.AuthParams, tasks []Task) []*Result
+}
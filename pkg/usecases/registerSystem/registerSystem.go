@@ -0,0 +1,241 @@
+package registerSystem
+
+import (
+	"encoding/json"
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	rs "mlmtool/pkg/models/registerSystem"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	util "mlmtool/pkg/util/contains"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// bootstrapInvocation is the command to be run on the new system to register it with the given activation key
+const bootstrapInvocation = "curl -Sks https://%[1]v/pub/bootstrap/bootstrap.sh -o /tmp/bootstrap.sh && " +
+	"sed -i 's/^ACTIVATION_KEYS=.*/ACTIVATION_KEYS=%[2]v/' /tmp/bootstrap.sh && bash /tmp/bootstrap.sh"
+
+type RegisterSystem struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                rs.InputData
+}
+
+func NewRegisterSystem(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input rs.InputData) *RegisterSystem {
+	return &RegisterSystem{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// RegisterSystem registers a new system. The given activation key is used, or the key matching the OS label from
+// osreleasedata.json is picked or created. The bootstrap invocation to be run on the system is printed, after which
+// the system is waited for. Once registered, the system is added to the given groups and the formulas are assigned.
+func (h *RegisterSystem) RegisterSystem() error {
+	log.Debug("RegisterSystem started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	err = h.validateRegisterSystem(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doRegisterSystem(authParm)
+	if err != nil {
+		return err
+	}
+	log.Info("RegisterSystem finished")
+	return nil
+}
+
+// validateRegisterSystem checks the parameters, that the system is not registered yet and the groups exist.
+func (h *RegisterSystem) validateRegisterSystem(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("registerSystem validateRegisterSystem started")
+	if len(h.input.Server) == 0 {
+		return fmt.Errorf("server is mandatory")
+	}
+	if len(h.input.ActivationKey) == 0 && len(h.input.OsLabel) == 0 {
+		return fmt.Errorf("activation key or os label is mandatory")
+	}
+	if len(h.input.ActivationKey) > 0 && len(h.input.OsLabel) > 0 {
+		return fmt.Errorf("activation key and os label cannot be given both")
+	}
+	systems, err := h.sumanProxy.SystemGetID(authParm, h.input.Server)
+	if err != nil {
+		return err
+	}
+	if len(systems) > 0 {
+		return fmt.Errorf("system %v is already registered with id %v", h.input.Server, systems[0].ID)
+	}
+	for _, group := range h.input.Groups {
+		_, err = h.sumanProxy.SystemGroupGetDetails(authParm, group)
+		if err != nil {
+			return fmt.Errorf("%v: %v", returnCodes.ErrSystemGroupNotFound, group)
+		}
+	}
+	log.Debug("registerSystem validateRegisterSystem finished")
+	return nil
+}
+
+// doRegisterSystem determines the activation key, prints the bootstrap invocation, waits for the system to
+// register and configures its groups and formulas.
+func (h *RegisterSystem) doRegisterSystem(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("doRegisterSystem started")
+	key := h.input.ActivationKey
+	var err error
+	if len(key) > 0 {
+		_, err = h.sumanProxy.ActivationKeyGetDetails(authParm, key)
+		if err != nil {
+			return fmt.Errorf("activation key %v doesn't exist", key)
+		}
+	} else {
+		key, err = h.activationKeyForOs(authParm)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Run the following command as root on %v to register it:\n\n", h.input.Server)
+	fmt.Printf(bootstrapInvocation+"\n\n", h.genConfig.Suman.Server, key)
+	systemID, err := h.waitForSystem(authParm)
+	if err != nil {
+		return err
+	}
+	for _, group := range h.input.Groups {
+		log.Info(fmt.Sprintf("adding system %v to group %v", h.input.Server, group))
+		err = h.sumanProxy.SystemGroupAddOrRemoveSystems(authParm, group, []int{systemID}, true)
+		if err != nil {
+			return err
+		}
+	}
+	if len(h.input.Formulas) > 0 {
+		// the formulas of the system are replaced, so the already assigned formulas are kept
+		formulas, err := h.sumanProxy.GetFormulasByServerID(authParm, systemID)
+		if err != nil {
+			return err
+		}
+		for _, formula := range h.input.Formulas {
+			if !util.Contains(formulas, formula) {
+				formulas = append(formulas, formula)
+			}
+		}
+		log.Info(fmt.Sprintf("assigning formulas %v to system %v", strings.Join(formulas, ", "), h.input.Server))
+		_, err = h.sumanProxy.FormulaSetFormulasOfSystem(authParm, systemID, formulas)
+		if err != nil {
+			return err
+		}
+	}
+	log.Debug("doRegisterSystem finished")
+	return nil
+}
+
+// activationKeyForOs returns the activation key for the OS label. An existing key named after the label with the
+// base channel from osreleasedata.json is used, otherwise the key is created with all child channels of the base channel.
+func (h *RegisterSystem) activationKeyForOs(authParm _sumanUseCase.AuthParams) (string, error) {
+	release, err := h.osRelease()
+	if err != nil {
+		return "", err
+	}
+	keys, err := h.sumanProxy.ActivationKeyListActivationKeys(authParm)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		// activation keys are prefixed with the organization id, like 1-s155
+		if (key.Key == release.Label || strings.HasSuffix(key.Key, "-"+release.Label)) && key.BaseChannelLabel == release.ParentChannel {
+			log.Info(fmt.Sprintf("using activation key %v", key.Key))
+			return key.Key, nil
+		}
+	}
+	exists, err := h.sumanProxy.ChannelSoftwareIsExisting(authParm, release.ParentChannel)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("base channel %v of os label %v doesn't exist", release.ParentChannel, release.Label)
+	}
+	log.Info(fmt.Sprintf("creating activation key %v with base channel %v", release.Label, release.ParentChannel))
+	key, err := h.sumanProxy.ActivationKeyCreate(authParm, release.Label, release.ParentChannel, []string{})
+	if err != nil {
+		return "", err
+	}
+	children, err := h.sumanProxy.ChannelSoftwareListChildren(authParm, release.ParentChannel)
+	if err != nil {
+		return "", err
+	}
+	var childLabels []string
+	for _, child := range children {
+		childLabels = append(childLabels, child.Label)
+	}
+	if len(childLabels) > 0 {
+		_, err = h.sumanProxy.ActivationKeyAddChildChannels(authParm, key, childLabels)
+		if err != nil {
+			return "", err
+		}
+	}
+	return key, nil
+}
+
+// osRelease reads osreleasedata.json and returns the entry for the OS label. When no file is given, the file is
+// read from dirs.scripts_dir.
+func (h *RegisterSystem) osRelease() (rs.OsRelease, error) {
+	fileName := h.input.OsDataFile
+	if len(fileName) == 0 {
+		fileName = filepath.Join(h.genConfig.Dirs.ScriptsDir, "osreleasedata.json")
+	}
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return rs.OsRelease{}, fmt.Errorf("unable to read %v: %v", fileName, err)
+	}
+	var osData rs.OsReleaseData
+	err = json.Unmarshal(data, &osData)
+	if err != nil {
+		return rs.OsRelease{}, fmt.Errorf("unable to parse %v: %v", fileName, err)
+	}
+	for _, release := range osData.OsRelease {
+		if release.Label == h.input.OsLabel {
+			return release, nil
+		}
+	}
+	return rs.OsRelease{}, fmt.Errorf("os label %v not found in %v", h.input.OsLabel, fileName)
+}
+
+// waitForSystem waits until the system is registered and returns its system id.
+func (h *RegisterSystem) waitForSystem(authParm _sumanUseCase.AuthParams) (int, error) {
+	log.Info(fmt.Sprintf("waiting for system %v to register", h.input.Server))
+	endTime := time.Now().Add(time.Second * time.Duration(h.suseoperationtimeout))
+	for {
+		systems, err := h.sumanProxy.SystemGetID(authParm, h.input.Server)
+		if err != nil {
+			log.Warn(fmt.Sprintf("unable to get id of system %v: %v", h.input.Server, err))
+		}
+		if len(systems) == 1 {
+			log.Info(fmt.Sprintf("system %v registered with id %v", h.input.Server, systems[0].ID))
+			return systems[0].ID, nil
+		}
+		if len(systems) > 1 {
+			return 0, fmt.Errorf("more than one system found with name %v", h.input.Server)
+		}
+		if time.Now().After(endTime) {
+			return 0, fmt.Errorf("system %v not registered within %v seconds", h.input.Server, h.suseoperationtimeout)
+		}
+		err = _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*30)
+		if err != nil {
+			return 0, fmt.Errorf("interrupted while waiting for system %v to register, the bootstrap may still be running: %w", h.input.Server, err)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+package registerSystem
+
+type IRegisterSystem interface {
+	RegisterSystem() error
+}
@@ -0,0 +1,176 @@
+package removeSoftwareProject
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	rsp "mlmtool/pkg/models/removeSoftwareProject"
+	"reflect"
+	"strings"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	util "mlmtool/pkg/util/contains"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+type RemoveSoftwareProject struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                rsp.InputData
+}
+
+func NewRemoveSoftwareProject(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input rsp.InputData) *RemoveSoftwareProject {
+	return &RemoveSoftwareProject{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// RemoveSoftwareProject removes the given content lifecycle project including its environments, sources and filters.
+// It refuses to remove the project when an activation key or system still uses one of the channels of the project.
+// Returns an error if any step in the process fails, including login, validation or one of the remove steps.
+func (h *RemoveSoftwareProject) RemoveSoftwareProject() error {
+	log.Debug("RemoveSoftwareProject started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	err = h.validateRemoveSoftwareProject(authParm)
+	if err != nil {
+		return err
+	}
+	environments, err := h.suse.OrderedEnvironments(authParm, h.input.Project)
+	if err != nil {
+		return err
+	}
+	err = h.checkProjectNotInUse(authParm, environments)
+	if err != nil {
+		return err
+	}
+	err = h.doRemoveSoftwareProject(authParm, environments)
+	if err != nil {
+		return err
+	}
+	log.Info("RemoveSoftwareProject finished")
+	return nil
+}
+
+// validateRemoveSoftwareProject checks that a project name is given and that the project exists.
+func (h *RemoveSoftwareProject) validateRemoveSoftwareProject(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("removeSoftwareProject validateRemoveSoftwareProject started")
+	if len(h.input.Project) == 0 {
+		return fmt.Errorf("project name is mandatory")
+	}
+	project, err := h.sumanProxy.ContentManagementLookupProject(authParm, h.input.Project)
+	if err != nil {
+		return err
+	}
+	if reflect.ValueOf(project).IsZero() {
+		return fmt.Errorf("project %v does not exist", h.input.Project)
+	}
+	log.Debug("removeSoftwareProject validateRemoveSoftwareProject finished")
+	return nil
+}
+
+// checkProjectNotInUse verifies that no activation key and no system is using a channel created by the project.
+// Every usage found is logged, so all of them can be cleaned up in one go.
+func (h *RemoveSoftwareProject) checkProjectNotInUse(authParm _sumanUseCase.AuthParams, environments []sumamodels.ContentManagementEnvironmentList) error {
+	log.Debug("checkProjectNotInUse started")
+	channels, err := h.sumanProxy.ChannelListSoftwareChannels(authParm)
+	if err != nil {
+		return err
+	}
+	var projectChannels []string
+	for _, channel := range channels {
+		for _, env := range environments {
+			if strings.HasPrefix(channel.Label, h.input.Project+"-"+env.Label+"-") {
+				projectChannels = append(projectChannels, channel.Label)
+				break
+			}
+		}
+	}
+	inUse := false
+	keys, err := h.sumanProxy.ActivationKeyListActivationKeys(authParm)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if util.Contains(projectChannels, key.BaseChannelLabel) {
+			log.Error(fmt.Sprintf("activation key %v uses base channel %v of project %v", key.Key, key.BaseChannelLabel, h.input.Project))
+			inUse = true
+		}
+		for _, child := range key.ChildChannelLabels {
+			if util.Contains(projectChannels, child) {
+				log.Error(fmt.Sprintf("activation key %v uses channel %v of project %v", key.Key, child, h.input.Project))
+				inUse = true
+			}
+		}
+	}
+	for _, channel := range projectChannels {
+		systems, err := h.sumanProxy.ChannelSoftwareListSubscribedSystems(authParm, channel)
+		if err != nil {
+			return err
+		}
+		for _, system := range systems {
+			log.Error(fmt.Sprintf("system %v is subscribed to channel %v of project %v", system.Name, channel, h.input.Project))
+			inUse = true
+		}
+	}
+	if inUse {
+		return fmt.Errorf("project %v is still in use. Please remove the activation keys and systems listed first", h.input.Project)
+	}
+	log.Debug("checkProjectNotInUse finished")
+	return nil
+}
+
+// doRemoveSoftwareProject removes the environments from last to first, detaches all sources and filters
+// and finally removes the project itself.
+func (h *RemoveSoftwareProject) doRemoveSoftwareProject(authParm _sumanUseCase.AuthParams, environments []sumamodels.ContentManagementEnvironmentList) error {
+	log.Debug("doRemoveSoftwareProject started")
+	for i := len(environments) - 1; i >= 0; i-- {
+		_, err := h.sumanProxy.ContentManagementRemoveEnvironment(authParm, h.input.Project, environments[i].Label)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("removed environment %v from project %v", environments[i].Label, h.input.Project))
+	}
+	sources, err := h.sumanProxy.ContentManagementListProjectSources(authParm, h.input.Project)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		err := h.sumanProxy.ContentManagementDetachSource(authParm, h.input.Project, source.Type, source.ChannelLabel)
+		if err != nil {
+			return err
+		}
+		log.Debug(fmt.Sprintf("detached source %v from project %v", source.ChannelLabel, h.input.Project))
+	}
+	filters, err := h.sumanProxy.ContentManagementListProjectFilters(authParm, h.input.Project)
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		err := h.sumanProxy.ContentManagementDetachFilter(authParm, h.input.Project, filter.Filter.ID)
+		if err != nil {
+			return err
+		}
+		log.Debug(fmt.Sprintf("detached filter %v from project %v", filter.Filter.Name, h.input.Project))
+	}
+	_, err = h.sumanProxy.ContentManagementRemoveProject(authParm, h.input.Project)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("removed project %v", h.input.Project))
+	log.Debug("doRemoveSoftwareProject finished")
+	return nil
+}
@@ -0,0 +1,5 @@
+package removeSoftwareProject
+
+type IRemoveSoftwareProject interface {
+	RemoveSoftwareProject() error
+}
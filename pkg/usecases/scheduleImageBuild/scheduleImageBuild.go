@@ -0,0 +1,153 @@
+package scheduleImageBuild
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	sib "mlmtool/pkg/models/scheduleImageBuild"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// statuses of the build and inspect of an image, as returned by image/listImages
+const (
+	statusCompleted = "completed"
+	statusFailed    = "failed"
+)
+
+type ScheduleImageBuild struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                sib.InputData
+}
+
+func NewScheduleImageBuild(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input sib.InputData) *ScheduleImageBuild {
+	return &ScheduleImageBuild{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// ScheduleImageBuild schedules the build of the image of the given profile on the build host and waits until the
+// image is built and inspected. The id of the image and the build and inspect status are printed.
+// Returns an error when the build or inspect failed or didn't finish within the timeout.
+func (h *ScheduleImageBuild) ScheduleImageBuild() error {
+	log.Debug("ScheduleImageBuild started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	buildHostID, err := h.validateScheduleImageBuild(authParm)
+	if err != nil {
+		return err
+	}
+	image, err := h.doScheduleImageBuild(authParm, buildHostID)
+	if image.ID > 0 {
+		fmt.Printf("image id: %v\nname: %v\nversion: %v-%v\nbuild status: %v\ninspect status: %v\n",
+			image.ID, image.Name, image.Version, image.Revision, image.BuildStatus, image.InspectStatus)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("ScheduleImageBuild finished")
+	return nil
+}
+
+// validateScheduleImageBuild checks the profile exists and returns the system id of the build host.
+func (h *ScheduleImageBuild) validateScheduleImageBuild(authParm _sumanUseCase.AuthParams) (int, error) {
+	log.Debug("scheduleImageBuild validateScheduleImageBuild started")
+	if len(h.input.Profile) == 0 || len(h.input.BuildHost) == 0 {
+		return 0, fmt.Errorf("profile and build host are mandatory")
+	}
+	if h.input.Timeout <= 0 {
+		h.input.Timeout = h.suseoperationtimeout
+	}
+	_, err := h.sumanProxy.ImageProfileGetDetails(authParm, h.input.Profile)
+	if err != nil {
+		return 0, fmt.Errorf("image profile %v doesn't exist", h.input.Profile)
+	}
+	systems, err := h.sumanProxy.SystemGetID(authParm, h.input.BuildHost)
+	if err != nil {
+		return 0, err
+	}
+	if len(systems) == 0 {
+		return 0, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, h.input.BuildHost)
+	}
+	if len(systems) > 1 {
+		return 0, fmt.Errorf("more than one system found with name %v", h.input.BuildHost)
+	}
+	log.Debug("scheduleImageBuild validateScheduleImageBuild finished")
+	return systems[0].ID, nil
+}
+
+// doScheduleImageBuild schedules the build, waits for the build action and then for the image to be built and inspected.
+func (h *ScheduleImageBuild) doScheduleImageBuild(authParm _sumanUseCase.AuthParams, buildHostID int) (sumamodels.ImageOverview, error) {
+	log.Debug("doScheduleImageBuild started")
+	endTime := time.Now().Add(time.Second * time.Duration(h.input.Timeout))
+	actionID, err := h.sumanProxy.ImageScheduleImageBuild(authParm, h.input.Profile, h.input.Version, buildHostID)
+	if err != nil {
+		return sumamodels.ImageOverview{}, err
+	}
+	log.Info(fmt.Sprintf("build of image profile %v scheduled on %v, action %v", h.input.Profile, h.input.BuildHost, actionID))
+	_, err = h.sumanProxy.CheckProgress(authParm, actionID, h.input.Timeout, "ImageScheduleImageBuild", buildHostID)
+	if err != nil {
+		return sumamodels.ImageOverview{}, err
+	}
+	for {
+		image, err := h.latestImage(authParm)
+		if err != nil {
+			log.Warn(fmt.Sprintf("unable to list images: %v", err))
+		}
+		if image.BuildStatus == statusFailed {
+			return image, fmt.Errorf("build of image %v failed", image.ID)
+		}
+		if image.InspectStatus == statusFailed {
+			return image, fmt.Errorf("inspect of image %v failed", image.ID)
+		}
+		if image.BuildStatus == statusCompleted && image.InspectStatus == statusCompleted {
+			log.Debug("doScheduleImageBuild finished")
+			return image, nil
+		}
+		if time.Now().After(endTime) {
+			return image, fmt.Errorf("image of profile %v not built and inspected within %v seconds", h.input.Profile, h.input.Timeout)
+		}
+		log.Info(fmt.Sprintf("waiting for image of profile %v, build %v, inspect %v", h.input.Profile, image.BuildStatus, image.InspectStatus))
+		err = _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*30)
+		if err != nil {
+			return image, fmt.Errorf("interrupted while image %v of profile %v is being built, the build continues on the server: %w", image.ID, h.input.Profile, err)
+		}
+	}
+}
+
+// latestImage returns the most recent image of the profile with the requested version.
+func (h *ScheduleImageBuild) latestImage(authParm _sumanUseCase.AuthParams) (sumamodels.ImageOverview, error) {
+	images, err := h.sumanProxy.ImageListImages(authParm)
+	if err != nil {
+		return sumamodels.ImageOverview{}, err
+	}
+	var latest sumamodels.ImageOverview
+	for _, image := range images {
+		if image.ProfileLabel != h.input.Profile {
+			continue
+		}
+		if len(h.input.Version) > 0 && image.Version != h.input.Version {
+			continue
+		}
+		if image.ID > latest.ID {
+			latest = image
+		}
+	}
+	return latest, nil
+}
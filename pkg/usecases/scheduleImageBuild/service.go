@@ -0,0 +1,5 @@
+package scheduleImageBuild
+
+type IScheduleImageBuild interface {
+	ScheduleImageBuild() error
+}
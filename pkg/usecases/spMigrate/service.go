@@ -0,0 +1,5 @@
+package spMigrate
+
+type ISpMigrate interface {
+	SpMigrate() error
+}
@@ -0,0 +1,288 @@
+package spMigrate
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	spm "mlmtool/pkg/models/spMigrate"
+	"regexp"
+	"strings"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	util "mlmtool/pkg/util/contains"
+	"mlmtool/pkg/util/errorhandling"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// servicePack matches the service pack in a channel label, like sles15-sp7
+var servicePack = regexp.MustCompile(`sp(\d+)`)
+
+// friendlyServicePack matches the service packs named in the friendly name of a migration target, like SP7
+var friendlyServicePack = regexp.MustCompile(`\bSP(\d+)\b`)
+
+type SpMigrate struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                spm.InputData
+}
+
+func NewSpMigrate(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input spm.InputData) *SpMigrate {
+	return &SpMigrate{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SpMigrate performs a service pack migration of the given system or of all active systems in the given group.
+// The target base channel is determined with maintenance.sp_migration_project for content lifecycle channels
+// and maintenance.sp_migration for other channels, where maintenance.exception_sp overrules the target per server.
+// Each migration is first run as dry run, then for real, after which the system is rebooted and the new base
+// channel is verified. Failures are handled according to error_handling.spmig and error_handling.reboot.
+func (h *SpMigrate) SpMigrate() error {
+	log.Debug("SpMigrate started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systems, err := h.validateSpMigrate(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doSpMigrate(authParm, systems)
+	if err != nil {
+		return err
+	}
+	log.Info("SpMigrate finished")
+	return nil
+}
+
+// validateSpMigrate checks either a server or a group is given and returns the systems to be migrated.
+func (h *SpMigrate) validateSpMigrate(authParm _sumanUseCase.AuthParams) ([]sumamodels.SystemGroupListSystemsMinimal, error) {
+	log.Debug("spMigrate validateSpMigrate started")
+	if len(h.input.Server) == 0 && len(h.input.Group) == 0 {
+		return nil, fmt.Errorf("server or group is mandatory")
+	}
+	if len(h.input.Server) > 0 && len(h.input.Group) > 0 {
+		return nil, fmt.Errorf("server and group cannot be given both")
+	}
+	if len(h.input.Server) > 0 {
+		systems, err := h.sumanProxy.SystemGetID(authParm, h.input.Server)
+		if err != nil {
+			return nil, err
+		}
+		if len(systems) == 0 {
+			return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, h.input.Server)
+		}
+		if len(systems) > 1 {
+			return nil, fmt.Errorf("more than one system found with name %v", h.input.Server)
+		}
+		return []sumamodels.SystemGroupListSystemsMinimal{{ID: systems[0].ID, Name: systems[0].Name}}, nil
+	}
+	active, err := h.suse.ActiveGroupSystems(authParm, h.input.Group)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("spMigrate validateSpMigrate finished")
+	return active, nil
+}
+
+// doSpMigrate migrates every system and prints a summary.
+func (h *SpMigrate) doSpMigrate(authParm _sumanUseCase.AuthParams, systems []sumamodels.SystemGroupListSystemsMinimal) error {
+	log.Debug("doSpMigrate started")
+	channels, err := h.sumanProxy.ChannelListSoftwareChannels(authParm)
+	if err != nil {
+		return err
+	}
+	var migrated, failed []string
+	for i, system := range systems {
+		if i > 0 {
+			err := _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*time.Duration(h.genConfig.Maintenance.WaitBetweenSystems))
+			if err != nil {
+				var notStarted []string
+				for _, system := range systems[i:] {
+					notStarted = append(notStarted, system.Name)
+				}
+				log.Info(fmt.Sprintf("migrated: %v", strings.Join(migrated, ", ")))
+				return fmt.Errorf("interrupted, migration not started for %v: %w", strings.Join(notStarted, ", "), err)
+			}
+		}
+		log.Info(fmt.Sprintf("service pack migration of system %v", system.Name))
+		ok, err := h.migrateSystem(authParm, system, channels)
+		if err != nil {
+			return err
+		}
+		if ok {
+			migrated = append(migrated, system.Name)
+		} else {
+			failed = append(failed, system.Name)
+		}
+	}
+	log.Info(fmt.Sprintf("migrated: %v", strings.Join(migrated, ", ")))
+	if len(failed) > 0 {
+		log.Error(fmt.Sprintf("failed: %v", strings.Join(failed, ", ")))
+		return fmt.Errorf("service pack migration failed for %v of %v systems", len(failed), len(systems))
+	}
+	log.Debug("doSpMigrate finished")
+	return nil
+}
+
+// migrateSystem performs the service pack migration of a single system. It returns false when the system
+// failed with a non-fatal policy, and an error when the failure is fatal.
+func (h *SpMigrate) migrateSystem(authParm _sumanUseCase.AuthParams, system sumamodels.SystemGroupListSystemsMinimal, channels []sumamodels.ChannelListSoftwareChannels) (bool, error) {
+	policies := h.genConfig.ErrorHandling
+	current, err := h.sumanProxy.SystemGetSubscribedBaseChannel(authParm, system.ID)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "fetching base channel", err)
+	}
+	targetBase, err := h.targetBaseChannel(authParm, system.Name, current.Label, channels)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "determining target base channel", err)
+	}
+	log.Info(fmt.Sprintf("system %v: migrating from %v to %v", system.Name, current.Label, targetBase))
+	target, err := h.migrationTarget(authParm, system.ID, targetBase)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "determining migration target", err)
+	}
+	children, err := h.sumanProxy.ChannelSoftwareListChildren(authParm, targetBase)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "fetching child channels", err)
+	}
+	var childLabels []string
+	for _, child := range children {
+		childLabels = append(childLabels, child.Label)
+	}
+	log.Info(fmt.Sprintf("system %v: dry run of migration to %v", system.Name, target.Friendly))
+	err = h.sumanProxy.SystemScheduleProductMigration(authParm, system.ID, target.Ident, targetBase, childLabels, true, h.suseoperationtimeout)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "dry run of service pack migration", err)
+	}
+	if h.input.DryRun {
+		return true, nil
+	}
+	log.Info(fmt.Sprintf("system %v: migration to %v", system.Name, target.Friendly))
+	err = h.sumanProxy.SystemScheduleProductMigration(authParm, system.ID, target.Ident, targetBase, childLabels, false, h.suseoperationtimeout)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "service pack migration", err)
+	}
+	log.Info(fmt.Sprintf("system %v: rebooting", system.Name))
+	err = h.sumanProxy.SystemScheduleReboot(authParm, system.ID, h.suseoperationtimeout)
+	if err != nil {
+		return false, h.handle(policies.Reboot, system.Name, "reboot", err)
+	}
+	migrated, err := h.sumanProxy.SystemGetSubscribedBaseChannel(authParm, system.ID)
+	if err != nil {
+		return false, h.handle(policies.Spmig, system.Name, "verifying base channel", err)
+	}
+	if migrated.Label != targetBase {
+		err = fmt.Errorf("base channel is %v instead of %v", migrated.Label, targetBase)
+		return false, h.handle(policies.Spmig, system.Name, "verifying base channel", err)
+	}
+	return true, nil
+}
+
+// targetBaseChannel determines the base channel the system should be migrated to.
+// For content lifecycle channels, labeled <project>-<environment>-<channel>, the project is replaced by the one
+// given in maintenance.sp_migration_project, keeping the environment. For other channels the part of the label
+// given in maintenance.sp_migration is replaced. A server listed in maintenance.exception_sp is migrated to the
+// project or product given as key instead.
+func (h *SpMigrate) targetBaseChannel(authParm _sumanUseCase.AuthParams, server string, label string, channels []sumamodels.ChannelListSoftwareChannels) (string, error) {
+	maintenance := h.genConfig.Maintenance
+	exception := h.exceptionTarget(server)
+	project := strings.SplitN(label, "-", 2)[0]
+	if targetProject, ok := maintenance.SpMigrationProjects[project]; ok {
+		if len(exception) > 0 {
+			targetProject = exception
+		}
+		environments, err := h.suse.OrderedEnvironments(authParm, project)
+		if err != nil {
+			return "", err
+		}
+		for _, environment := range environments {
+			prefix := fmt.Sprintf("%v-%v-", project, environment.Label)
+			if !strings.HasPrefix(label, prefix) {
+				continue
+			}
+			targetPrefix := fmt.Sprintf("%v-%v-", targetProject, environment.Label)
+			var candidates []string
+			for _, channel := range channels {
+				if len(channel.ParentLabel) == 0 && strings.HasPrefix(channel.Label, targetPrefix) {
+					candidates = append(candidates, channel.Label)
+				}
+			}
+			if len(candidates) != 1 {
+				return "", fmt.Errorf("expected one base channel starting with %v, found %v", targetPrefix, len(candidates))
+			}
+			return candidates[0], nil
+		}
+		return "", fmt.Errorf("no environment of project %v found for base channel %v", project, label)
+	}
+	for source, target := range maintenance.SpMigrations {
+		if !strings.Contains(label, source) {
+			continue
+		}
+		if len(exception) > 0 {
+			target = exception
+		}
+		targetLabel := strings.Replace(label, source, target, 1)
+		for _, channel := range channels {
+			if channel.Label == targetLabel && len(channel.ParentLabel) == 0 {
+				return targetLabel, nil
+			}
+		}
+		return "", fmt.Errorf("target base channel %v does not exist", targetLabel)
+	}
+	return "", fmt.Errorf("no service pack migration defined for base channel %v", label)
+}
+
+// exceptionTarget returns the target from maintenance.exception_sp the server is listed for, by full or short hostname.
+func (h *SpMigrate) exceptionTarget(server string) string {
+	shortName := strings.Split(server, ".")[0]
+	for target, servers := range h.genConfig.Maintenance.ExceptionSp {
+		if util.Contains(servers, server) || util.Contains(servers, shortName) {
+			return target
+		}
+	}
+	return ""
+}
+
+// migrationTarget selects the product migration target matching the service pack of the target base channel.
+// When the service pack cannot be derived from the label, the system must have exactly one migration target.
+func (h *SpMigrate) migrationTarget(authParm _sumanUseCase.AuthParams, systemID int, targetBase string) (sumamodels.MigrationTarget, error) {
+	targets, err := h.sumanProxy.SystemListMigrationTargets(authParm, systemID)
+	if err != nil {
+		return sumamodels.MigrationTarget{}, err
+	}
+	if len(targets) == 0 {
+		return sumamodels.MigrationTarget{}, fmt.Errorf("no migration targets available")
+	}
+	if match := servicePack.FindStringSubmatch(strings.ToLower(targetBase)); match != nil {
+		for _, target := range targets {
+			for _, sp := range friendlyServicePack.FindAllStringSubmatch(strings.ToUpper(target.Friendly), -1) {
+				if sp[1] == match[1] {
+					return target, nil
+				}
+			}
+		}
+		return sumamodels.MigrationTarget{}, fmt.Errorf("no migration target found for SP%v", match[1])
+	}
+	if len(targets) > 1 {
+		return sumamodels.MigrationTarget{}, fmt.Errorf("more than one migration target available for %v", targetBase)
+	}
+	return targets[0], nil
+}
+
+// handle applies the given error_handling policy, or the timeout_passed policy when the action ran into a timeout.
+func (h *SpMigrate) handle(policy string, server string, step string, err error) error {
+	return errorhandling.HandleAction(h.genConfig.ErrorHandling, policy, fmt.Sprintf("%v of system %v", step, server), err)
+}
@@ -0,0 +1,89 @@
+package spMigrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/models/inputfile"
+	spm "mlmtool/pkg/models/spMigrate"
+	sumamodels "mlmtool/pkg/models/susemanager"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestMigrationTarget(t *testing.T) {
+	sp1 := sumamodels.MigrationTarget{Ident: "[1,2]", Friendly: "[ ] SUSE Linux Enterprise Server 15 SP1 x86_64"}
+	sp12 := sumamodels.MigrationTarget{Ident: "[3,4]", Friendly: "[ ] SUSE Linux Enterprise Server 12 SP5 x86_64, Basesystem Module 15 SP12 x86_64"}
+	sp2 := sumamodels.MigrationTarget{Ident: "[5,6]", Friendly: "[ ] SUSE Linux Enterprise Server 15 SP2 x86_64"}
+	tests := []struct {
+		name           string
+		targetBase     string
+		targets        []sumamodels.MigrationTarget
+		expectError    bool
+		expectedTarget sumamodels.MigrationTarget
+	}{
+		{
+			name:           "service pack matched",
+			targetBase:     "sles15-sp2-pool-x86_64",
+			targets:        []sumamodels.MigrationTarget{sp1, sp2},
+			expectedTarget: sp2,
+		},
+		{
+			name:           "two digit service pack not matched as prefix",
+			targetBase:     "sles15-sp1-pool-x86_64",
+			targets:        []sumamodels.MigrationTarget{sp12, sp1},
+			expectedTarget: sp1,
+		},
+		{
+			name:        "service pack not available",
+			targetBase:  "sles15-sp1-pool-x86_64",
+			targets:     []sumamodels.MigrationTarget{sp12, sp2},
+			expectError: true,
+		},
+		{
+			name:           "single target without service pack in label",
+			targetBase:     "sles15-pool-x86_64",
+			targets:        []sumamodels.MigrationTarget{sp2},
+			expectedTarget: sp2,
+		},
+		{
+			name:        "several targets without service pack in label",
+			targetBase:  "sles15-pool-x86_64",
+			targets:     []sumamodels.MigrationTarget{sp1, sp2},
+			expectError: true,
+		},
+		{
+			name:        "no targets",
+			targetBase:  "sles15-sp2-pool-x86_64",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			systemID := fake.AddSystem(fakesuma.System{Name: "web01.example.com", MigrationTargets: tt.targets})
+			proxy := fake.Proxy()
+			sessionKey, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			h := NewSpMigrate(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 60, genConfig, spm.InputData{})
+
+			target, err := h.migrationTarget(_sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: sessionKey}, systemID, tt.targetBase)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTarget, target)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Package susemanager api call for SUSE Manager related to audit
+package susemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	log "mlmtool/pkg/util/logger"
+	"net/http"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// AuditListSystemsByPatchStatus - list the patch status of all systems for the given CVE
+//
+// param: auth
+// param: cveIdentifier
+// return: []sumamodels.AuditSystem, error
+func (p *Proxy) AuditListSystemsByPatchStatus(auth AuthParams, cveIdentifier string) ([]sumamodels.AuditSystem, error) {
+	body, err := json.Marshal(map[string]interface{}{"cveIdentifier": cveIdentifier})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "audit/listSystemsByPatchStatus"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var systems []sumamodels.AuditSystem
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &systems)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching patch status of %v Failed. Http StatusCode: %v Http Response body: %v", cveIdentifier, response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return systems, nil
+}
@@ -2,30 +2,23 @@
 package susemanager
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
 	log "mlmtool/pkg/util/logger"
-	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+const logoutTimeout = 10 * time.Second
+
 // implements all suse manager API calls here which act as a proxy
 
-// SumanLogin - login to SUSE Manager
+// SumanLogin - login to SUSE Manager. All logins of a proxy share one session of the session manager.
 //
 // return:
 func (p *Proxy) SumanLogin() (string, error) {
-	reqBody, _ := json.Marshal(map[string]interface{}{
-		"login":    strings.TrimSpace(p.cfg.Login),
-		"password": strings.TrimSpace(p.cfg.Password)})
-	sumaCookie, err := p.GetSessionKey(reqBody, p.cfg.Host)
-	if err != nil {
-		return "", err
-	}
-	log.Debug("Successfull login to suse manager", zap.Any("host", p.cfg.Host))
-	return sumaCookie, nil
+	return p.sessions.Session()
 }
 
 // GetSessionKey - get session key
@@ -34,28 +27,20 @@ func (p *Proxy) SumanLogin() (string, error) {
 // param: host
 // return:
 func (p *Proxy) GetSessionKey(body []byte, host string) (string, error) {
-	response, err := p.suse.SuseManagerCall(body, "POST", host, "auth/login", "")
-	if err != nil {
-		log.Error("error while login to suse manager", zap.Any("error", err))
-		return "", errors.New("error while login to suse manager")
-	}
-	_, err = HandleSuseManagerResponse(response.Body)
-	if err != nil {
-		log.Fatal("Unable to retrieve Cookie. Login problem", zap.Any("host", p.cfg.Host), zap.Any("Error", err))
-	} else {
-		log.Debug("succesfully retrieved Cookie.", zap.Any("host", p.cfg.Host))
-	}
-	Cookie := response.Cookies
-	sumaCookie := fmt.Sprint(Cookie[2])
-	return sumaCookie, nil
+	return getSessionKey(p.suse, body, host)
 }
 
-// SumanLogout - logout from SUSE Manager
+// SumanLogout - logout from SUSE Manager. The logout is sent even when the context of the api is already cancelled.
 //
 // param: auth
 func (p *Proxy) SumanLogout(auth AuthParams) error {
+	if p.sessions.Owns(auth.SessionKey) {
+		return p.sessions.Logout()
+	}
 	path := "auth/logout"
-	_, err := p.suse.SuseManagerCall(nil, "GET", auth.Host, path, auth.SessionKey)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(p.suse.Context()), logoutTimeout)
+	defer cancel()
+	_, err := p.suse.SuseManagerCallContext(ctx, nil, "GET", auth.Host, path, auth.SessionKey)
 	if err != nil {
 		log.Error("Unable to logout the request from suse manager", zap.Any("error", err))
 		return errors.New("error while logout suse manager")
@@ -63,3 +48,15 @@ func (p *Proxy) SumanLogout(auth AuthParams) error {
 	log.Debug("Successfully logout from SUSE Manager Server", zap.Any("host", p.cfg.Host))
 	return nil
 }
+
+// SumanLogoutAll - end the session of the proxy. It is logged out unless it is kept in the session cache.
+func (p *Proxy) SumanLogoutAll() {
+	p.sessions.Close()
+}
+
+// Context - context of all api calls, cancelled when the run is interrupted
+//
+// return: context.Context
+func (p *Proxy) Context() context.Context {
+	return p.suse.Context()
+}
@@ -0,0 +1,115 @@
+package susemanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestInterrupt(t *testing.T) {
+	tests := []struct {
+		name        string
+		actionState string
+		cancel      bool
+		failPath    string
+		expectError bool
+	}{
+		{
+			name:        "action completed",
+			actionState: fakesuma.StateCompleted,
+		},
+		{
+			name:        "interrupted while action in progress",
+			actionState: fakesuma.StateInProgress,
+			cancel:      true,
+			expectError: true,
+		},
+		{
+			name:        "listing systems in progress failed",
+			actionState: fakesuma.StateInProgress,
+			failPath:    "schedule/listInProgressSystems",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.ActionState = tt.actionState
+			systemID := fake.AddSystem(fakesuma.System{Name: "web01.example.com"})
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			proxy := fake.ProxyContext(ctx)
+			sessionKey, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			auth := _sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: sessionKey}
+			actionID, err := proxy.SystemScheduleRebootAction(auth, systemID)
+			assert.NoError(t, err)
+			if tt.cancel {
+				cancel()
+			}
+			if tt.failPath != "" {
+				fake.Fail(tt.failPath, "Could not list systems", 0)
+			}
+
+			_, err = proxy.CheckProgress(auth, actionID, 60, "reboot", systemID)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, tt.cancel, errors.Is(err, context.Canceled))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 1, fake.Sessions())
+			proxy.SumanLogoutAll()
+			assert.Equal(t, 0, fake.Sessions())
+		})
+	}
+}
+
+func TestCancelledCall(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(proxy _sumanUseCase.IProxy, auth _sumanUseCase.AuthParams, systemID int) error
+	}{
+		{
+			name: "system id",
+			call: func(proxy _sumanUseCase.IProxy, auth _sumanUseCase.AuthParams, _ int) error {
+				_, err := proxy.SystemGetID(auth, "web01.example.com")
+				return err
+			},
+		},
+		{
+			name: "package refresh",
+			call: func(proxy _sumanUseCase.IProxy, auth _sumanUseCase.AuthParams, systemID int) error {
+				return proxy.SchedulePackageRefresh(auth, systemID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			systemID := fake.AddSystem(fakesuma.System{Name: "web01.example.com"})
+			ctx, cancel := context.WithCancel(context.Background())
+			proxy := fake.ProxyContext(ctx)
+			sessionKey, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			cancel()
+
+			err = tt.call(proxy, _sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: sessionKey}, systemID)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -78,9 +78,25 @@ func (p *Proxy) ChannelSoftwareListChildren(auth AuthParams, label string) ([]su
 	return resultSuc, nil
 }
 
-func (p *Proxy) ChannelSoftwareCreateRepo(auth AuthParams, label string, typeRepo string, url string) (sumamodels.ChannelSoftwareCreateRepo, error) {
+// ChannelSoftwareCreateRepo - create a repository, optionally with the descriptions of the ssl certificates to use
+//
+// param: auth
+// param: label
+// param: typeRepo
+// param: url
+// param: sslCaCert
+// param: sslCliCert
+// param: sslCliKey
+// return:
+func (p *Proxy) ChannelSoftwareCreateRepo(auth AuthParams, label string, typeRepo string, url string, sslCaCert string, sslCliCert string, sslCliKey string) (sumamodels.ChannelSoftwareCreateRepo, error) {
 	log.Debug("Inside ChannelSoftwareCreateRepo function")
-	body, err := json.Marshal(map[string]interface{}{"label": label, "type": typeRepo, "url": url})
+	params := map[string]interface{}{"label": label, "type": typeRepo, "url": url}
+	if len(sslCaCert) > 0 {
+		params["sslCaCert"] = sslCaCert
+		params["sslCliCert"] = sslCliCert
+		params["sslCliKey"] = sslCliKey
+	}
+	body, err := json.Marshal(params)
 	var resultSuc sumamodels.ChannelSoftwareCreateRepo
 	if err != nil {
 		log.Warn(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
@@ -249,3 +265,316 @@ func (p *Proxy) ChannelSoftwareIsExisting(auth AuthParams, label string) (bool,
 	log.Debug("Completed ChannelSoftwareIsExisting function")
 	return resultSuc, nil
 }
+
+// ChannelSoftwareListSubscribedSystems - list the systems subscribed to the given channel
+//
+// param: auth
+// param: label
+// return:
+func (p *Proxy) ChannelSoftwareListSubscribedSystems(auth AuthParams, label string) ([]sumamodels.ChannelSoftwareSubscribedSystem, error) {
+	log.Debug("Inside ChannelSoftwareListSubscribedSystems function", zap.Any("Label", label))
+	var resultSuc []sumamodels.ChannelSoftwareSubscribedSystem
+	body, err := json.Marshal(map[string]interface{}{"channelLabel": label})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return nil, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/listSubscribedSystems"
+	response, err := p.suse.SuseManagerCall(body, "GET", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return nil, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &resultSuc)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return nil, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return nil, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareListSubscribedSystems function")
+	return resultSuc, nil
+}
+
+// ChannelSoftwareGetDetails - get the details of the given software channel
+//
+// param: auth
+// param: label
+// return:
+func (p *Proxy) ChannelSoftwareGetDetails(auth AuthParams, label string) (sumamodels.ChannelSoftwareListChildren, error) {
+	log.Debug("Inside ChannelSoftwareGetDetails function", zap.Any("Label", label))
+	var resultSuc sumamodels.ChannelSoftwareListChildren
+	body, err := json.Marshal(map[string]interface{}{"channelLabel": label})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return resultSuc, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/getDetails"
+	response, err := p.suse.SuseManagerCall(body, "GET", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return resultSuc, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return resultSuc, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &resultSuc)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return resultSuc, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return resultSuc, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareGetDetails function")
+	return resultSuc, nil
+}
+
+// ChannelSoftwareGetDetailsByID - returns the details of the software channel with the given id
+//
+// param: auth
+// param: channelID
+// return:
+func (p *Proxy) ChannelSoftwareGetDetailsByID(auth AuthParams, channelID int) (sumamodels.ChannelSoftwareListChildren, error) {
+	log.Debug("Inside ChannelSoftwareGetDetailsByID function", zap.Any("ChannelID", channelID))
+	var resultSuc sumamodels.ChannelSoftwareListChildren
+	body, err := json.Marshal(map[string]interface{}{"channelId": channelID})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return resultSuc, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/getDetails"
+	response, err := p.suse.SuseManagerCall(body, "GET", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return resultSuc, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return resultSuc, faultError(err, returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &resultSuc)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return resultSuc, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return resultSuc, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareGetDetailsByID function")
+	return resultSuc, nil
+}
+
+// ChannelSoftwareDelete - delete the given software channel
+//
+// param: auth
+// param: label
+// return:
+func (p *Proxy) ChannelSoftwareDelete(auth AuthParams, label string) error {
+	log.Debug("Inside ChannelSoftwareDelete function")
+	body, err := json.Marshal(map[string]interface{}{"channelLabel": label})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/delete"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareDelete function")
+	return nil
+}
+
+// ChannelSoftwareDisassociateRepo - disassociate the given repository from the given software channel
+//
+// param: auth
+// param: channelLabel
+// param: repoLabel
+// return:
+func (p *Proxy) ChannelSoftwareDisassociateRepo(auth AuthParams, channelLabel string, repoLabel string) error {
+	log.Debug("Inside ChannelSoftwareDisassociateRepo function")
+	body, err := json.Marshal(map[string]interface{}{"channelLabel": channelLabel, "repoLabel": repoLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/disassociateRepo"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareDisassociateRepo function")
+	return nil
+}
+
+// ChannelSoftwareRemoveRepo - remove the given repository
+//
+// param: auth
+// param: label
+// return:
+func (p *Proxy) ChannelSoftwareRemoveRepo(auth AuthParams, label string) error {
+	log.Debug("Inside ChannelSoftwareRemoveRepo function")
+	body, err := json.Marshal(map[string]interface{}{"label": label})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/removeRepo"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareRemoveRepo function")
+	return nil
+}
+
+// ChannelSoftwareSetDetails - change the details, like the gpg key, of the given software channel
+//
+// param: auth
+// param: channelLabel
+// param: details
+// return:
+func (p *Proxy) ChannelSoftwareSetDetails(auth AuthParams, channelLabel string, details map[string]interface{}) error {
+	log.Debug("Inside ChannelSoftwareSetDetails function")
+	body, err := json.Marshal(map[string]interface{}{"channelLabel": channelLabel, "details": details})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/setDetails"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareSetDetails function")
+	return nil
+}
+
+// ChannelSoftwareUpdateRepoURL - change the url of the given repository
+//
+// param: auth
+// param: label
+// param: url
+// return:
+func (p *Proxy) ChannelSoftwareUpdateRepoURL(auth AuthParams, label string, url string) error {
+	log.Debug("Inside ChannelSoftwareUpdateRepoURL function")
+	body, err := json.Marshal(map[string]interface{}{"label": label, "url": url})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "channel/software/updateRepoUrl"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareUpdateRepoURL function")
+	return nil
+}
+
+// ChannelSoftwareListUserRepos - list the repositories created by the user
+//
+// param: auth
+// return:
+func (p *Proxy) ChannelSoftwareListUserRepos(auth AuthParams) ([]sumamodels.ChannelSoftwareCreateRepo, error) {
+	log.Debug("Inside ChannelSoftwareListUserRepos function")
+	var resultSuc []sumamodels.ChannelSoftwareCreateRepo
+	path := "channel/software/listUserRepos"
+	response, err := p.suse.SuseManagerCall(nil, "GET", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+		return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("response", resp), zap.Any("error", err))
+			return nil, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &resultSuc)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return nil, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return nil, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug("Completed ChannelSoftwareListUserRepos function")
+	return resultSuc, nil
+}
@@ -504,3 +504,188 @@ func (p *Proxy) ContentManagementPromoteProject(auth AuthParams, projectLabel st
 	}
 	return result, nil
 }
+
+// ContentManagementListProjectSources - list the sources attached to a project
+//
+// param: auth
+// param: projectLabel
+// return:
+func (p *Proxy) ContentManagementListProjectSources(auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementSource, error) {
+	log.Debug("contentManagement.listProjectSources function called")
+	var result []sumamodels.ContentManagementSource
+	path := "contentmanagement/listProjectSources"
+	body, err := json.Marshal(map[string]any{"projectLabel": projectLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return result, errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return result, nil
+}
+
+// ContentManagementListProjectFilters - list the filters attached to a project
+//
+// param: auth
+// param: projectLabel
+// return:
+func (p *Proxy) ContentManagementListProjectFilters(auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementProjectFilter, error) {
+	log.Debug("contentManagement.listProjectFilters function called")
+	var result []sumamodels.ContentManagementProjectFilter
+	path := "contentmanagement/listProjectFilters"
+	body, err := json.Marshal(map[string]any{"projectLabel": projectLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return result, errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return result, nil
+}
+
+// ContentManagementDetachFilter - detach a filter from a project
+//
+// param: auth
+// param: projectLabel
+// param: filterID
+// return:
+func (p *Proxy) ContentManagementDetachFilter(auth AuthParams, projectLabel string, filterID int) error {
+	log.Debug("contentManagement.detachFilter function called")
+	path := "contentmanagement/detachFilter"
+	body, err := json.Marshal(map[string]any{"projectLabel": projectLabel, "filterId": filterID})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+		return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		_, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+			return errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return nil
+}
+
+// ContentManagementRemoveEnvironment - remove an environment from a project
+//
+// param: auth
+// param: projectLabel
+// param: envLabel
+// return:
+func (p *Proxy) ContentManagementRemoveEnvironment(auth AuthParams, projectLabel string, envLabel string) (int, error) {
+	log.Debug("contentManagement.removeEnvironment function called")
+	var result int
+	path := "contentmanagement/removeEnvironment"
+	body, err := json.Marshal(map[string]any{"projectLabel": projectLabel, "envLabel": envLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return result, errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return result, nil
+}
+
+// ContentManagementRemoveProject - remove a project
+//
+// param: auth
+// param: projectLabel
+// return:
+func (p *Proxy) ContentManagementRemoveProject(auth AuthParams, projectLabel string) (int, error) {
+	log.Debug("contentManagement.removeProject function called")
+	var result int
+	path := "contentmanagement/removeProject"
+	body, err := json.Marshal(map[string]any{"projectLabel": projectLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHandlingSuseManagerResponse, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return result, errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return result, nil
+}
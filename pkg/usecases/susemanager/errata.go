@@ -0,0 +1,52 @@
+// Package susemanager api call for SUSE Manager related to errata
+package susemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	log "mlmtool/pkg/util/logger"
+	"net/http"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// ErrataApplicableToChannels - list the channels the given erratum is available in
+//
+// param: auth
+// param: advisoryName
+// return: []sumamodels.ErrataChannel, error
+func (p *Proxy) ErrataApplicableToChannels(auth AuthParams, advisoryName string) ([]sumamodels.ErrataChannel, error) {
+	body, err := json.Marshal(map[string]interface{}{"advisoryName": advisoryName})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "errata/applicableToChannels"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var channels []sumamodels.ErrataChannel
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &channels)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching channels of erratum %v Failed. Http StatusCode: %v Http Response body: %v", advisoryName, response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return channels, nil
+}
@@ -0,0 +1,262 @@
+// Package susemanager api call for SUSE Manager related to images
+package susemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	log "mlmtool/pkg/util/logger"
+	"net/http"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// ImageListImages - list the images built or imported
+//
+// param: auth
+// return: []sumamodels.ImageOverview, error
+func (p *Proxy) ImageListImages(auth AuthParams) ([]sumamodels.ImageOverview, error) {
+	path := "image/listImages"
+	response, err := p.suse.SuseManagerCall(nil, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result []sumamodels.ImageOverview
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("listing images Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
+
+// ImageProfileCreate - create an image profile. The kiwi options are only used for kiwi profiles
+//
+// param: auth
+// param: label
+// param: imageType
+// param: storeLabel
+// param: imagePath
+// param: activationKey
+// param: kiwiOptions
+// return: error
+func (p *Proxy) ImageProfileCreate(auth AuthParams, label string, imageType string, storeLabel string, imagePath string, activationKey string, kiwiOptions string) error {
+	body, err := json.Marshal(map[string]interface{}{"label": label, "type": imageType, "storeLabel": storeLabel, "path": imagePath, "activationKey": activationKey, "kiwiOptions": kiwiOptions})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "image/profile/create"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		log.Debug(fmt.Sprintf("ImageProfileCreate result: %v", resp))
+	} else {
+		log.Error(fmt.Sprintf("creating image profile Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return nil
+}
+
+// ImageProfileDelete - delete the given image profile
+//
+// param: auth
+// param: label
+// return: error
+func (p *Proxy) ImageProfileDelete(auth AuthParams, label string) error {
+	body, err := json.Marshal(map[string]interface{}{"label": label})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "image/profile/delete"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		log.Debug(fmt.Sprintf("ImageProfileDelete result: %v", resp))
+	} else {
+		log.Error(fmt.Sprintf("deleting image profile Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return nil
+}
+
+// ImageProfileGetDetails - returns the details of the given image profile
+//
+// param: auth
+// param: label
+// return: sumamodels.ImageProfile, error
+func (p *Proxy) ImageProfileGetDetails(auth AuthParams, label string) (sumamodels.ImageProfile, error) {
+	body, err := json.Marshal(map[string]interface{}{"label": label})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "image/profile/getDetails"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result sumamodels.ImageProfile
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching image profile Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return sumamodels.ImageProfile{}, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
+
+// ImageProfileListImageProfiles - list the image profiles
+//
+// param: auth
+// return: []sumamodels.ImageProfile, error
+func (p *Proxy) ImageProfileListImageProfiles(auth AuthParams) ([]sumamodels.ImageProfile, error) {
+	path := "image/profile/listImageProfiles"
+	response, err := p.suse.SuseManagerCall(nil, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result []sumamodels.ImageProfile
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("listing image profiles Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
+
+// ImageScheduleImageBuild - schedule the build of the image of the given profile on the build host
+//
+// param: auth
+// param: profileLabel
+// param: version
+// param: buildHostID
+// return: int, error
+func (p *Proxy) ImageScheduleImageBuild(auth AuthParams, profileLabel string, version string, buildHostID int) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{"profileLabel": profileLabel, "version": version, "buildHostId": buildHostID, "earliestOccurrence": time.Now()})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "image/scheduleImageBuild"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result int
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return 0, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("scheduling image build Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
+
+// ImageStoreListImageStores - list the image stores
+//
+// param: auth
+// return: []sumamodels.ImageStore, error
+func (p *Proxy) ImageStoreListImageStores(auth AuthParams) ([]sumamodels.ImageStore, error) {
+	path := "image/store/listImageStores"
+	response, err := p.suse.SuseManagerCall(nil, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result []sumamodels.ImageStore
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("listing image stores Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
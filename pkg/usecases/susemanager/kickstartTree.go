@@ -34,7 +34,7 @@ func (p *Proxy) KickstartTreeGetDetails(auth AuthParams, distributionName string
 		resp, err := HandleSuseManagerResponse(response.Body)
 		if err != nil {
 			log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
-			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+			return result, faultError(err, returnCodes.ErrHandlingSuseManagerResponse)
 		}
 		byteArray, _ := json.Marshal(resp)
 		err = json.Unmarshal(byteArray, &result)
@@ -242,6 +242,44 @@ func (p *Proxy) KickstartDeleteProfile(auth AuthParams, profileLabel string) (in
 	return result, nil
 }
 
+// KickstartRenameProfile
+//
+// param: auth
+// param: originalLabel
+// param: newLabel
+// return:
+func (p *Proxy) KickstartRenameProfile(auth AuthParams, originalLabel string, newLabel string) (int, error) {
+	log.Debug("Kickstart.renameProfile called")
+	var result int
+	body, err := json.Marshal(map[string]any{"originalLabel": originalLabel, "newLabel": newLabel})
+	if err != nil {
+		log.Error(returnCodes.ErrFailedMarshalling, zap.Any("error", err))
+		return result, errors.New(returnCodes.ErrFailedMarshalling)
+	}
+	path := "kickstart/renameProfile"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return result, err
+	}
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(returnCodes.ErrFailedUnMarshalling, zap.Any("error", err))
+			return result, errors.New(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(returnCodes.ErrHTTPSuseManagerResponse, zap.Any("HTTP Statuscode", response.StatusCode))
+		return result, errors.New(returnCodes.ErrHTTPSuseManagerResponse)
+	}
+	return result, nil
+}
+
 func (p *Proxy) KickstartProfileSetVariables(auth AuthParams, profileLabel string, profileVariables interface{}) (int, error) {
 	log.Debug("Kickstart.profile.setVariables called", zap.Any("profileLabel", profileLabel), zap.Any("profileVariables", profileVariables))
 	var result int
@@ -0,0 +1,51 @@
+// Package susemanager api call for SUSE Manager related to proxies
+package susemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	log "mlmtool/pkg/util/logger"
+	"net/http"
+
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// ProxyListProxyClients - list the ids of the systems connected through the given proxy
+//
+// param: auth
+// param: proxyID
+// return: []int, error
+func (p *Proxy) ProxyListProxyClients(auth AuthParams, proxyID int) ([]int, error) {
+	body, err := json.Marshal(map[string]interface{}{"proxyId": proxyID})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "proxy/listProxyClients"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var clients []int
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &clients)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching proxy clients Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return clients, nil
+}
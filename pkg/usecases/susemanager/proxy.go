@@ -2,6 +2,7 @@
 package susemanager
 
 import (
+	"context"
 	"fmt"
 
 	"mlmtool/pkg/util/logger"
@@ -11,25 +12,34 @@ import (
 
 // SuMaAPI description for API call
 type SuMaAPI struct {
-	basepath   string
-	insecure   bool
-	susestub   bool
-	retrycount int
+	basepath string
+	client   *rest.Client
+	susestub bool
 }
 
 // NewSuseManagerAPI - open new API call to SUSE Manager
 //
 // param: basepath
 // param: insecure
-// param: logger
 // param: retrycount
 // param: susestub
 // return:
 func NewSuseManagerAPI(basepath string, insecure bool, retrycount int, susestub ...bool) ISuseManagerAPI {
+	// without a CA bundle creating the client cannot fail
+	client, _ := rest.NewClient(rest.ClientConfig{Insecure: insecure, RetryCount: retrycount})
+	return NewSuseManagerAPIClient(basepath, client, susestub...)
+}
+
+// NewSuseManagerAPIClient - open new API call to SUSE Manager using the given client
+//
+// param: basepath
+// param: client
+// param: susestub
+// return:
+func NewSuseManagerAPIClient(basepath string, client *rest.Client, susestub ...bool) ISuseManagerAPI {
 	var s = &SuMaAPI{
-		basepath:   basepath,
-		insecure:   insecure,
-		retrycount: retrycount,
+		basepath: basepath,
+		client:   client,
 	}
 	// default suse stub to false
 	s.susestub = false
@@ -39,15 +49,16 @@ func NewSuseManagerAPI(basepath string, insecure bool, retrycount int, susestub
 	return s
 }
 
-// SuseManagerCall - Call api at SUSE Manager
+// SuseManagerCall - Call api at SUSE Manager. Cancelling ctx aborts the call.
 //
+// param: ctx
 // param: body
 // param: method
 // param: hostname
 // param: path
 // param: sessionKey
 // return:
-func (s *SuMaAPI) SuseManagerCall(body []byte, method string, hostname string, path string, sessionKey string) (output *rest.HTTPHelperStruct, er error) {
+func (s *SuMaAPI) SuseManagerCall(ctx context.Context, body []byte, method string, hostname string, path string, sessionKey string) (output *rest.HTTPHelperStruct, er error) {
 	header := make(map[string]string)
 	header["Cookie"] = sessionKey
 	var httproto = "https"
@@ -55,9 +66,9 @@ func (s *SuMaAPI) SuseManagerCall(body []byte, method string, hostname string, p
 		httproto = "http"
 	}
 	url := fmt.Sprintf("%s://%s/%s/%s", httproto, hostname, s.basepath, path)
-	response, err := rest.HTTPHelper(s.retrycount, body, method, url, s.insecure, header)
+	response, err := s.client.Do(ctx, body, method, url, header)
 	if err != nil {
-		logger.Error("Error message recieved: %w", err)
+		logger.Error(fmt.Sprintf("Error message recieved: %v", err))
 		return nil, err
 	}
 	return response, nil
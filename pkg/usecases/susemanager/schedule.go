@@ -0,0 +1,179 @@
+// Package susemanager api call for SUSE Manager related to scheduled actions
+package susemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	log "mlmtool/pkg/util/logger"
+	"net/http"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// ScheduleArchiveActions - archive the given actions
+//
+// param: auth
+// param: actionIDs
+// return: error
+func (p *Proxy) ScheduleArchiveActions(auth AuthParams, actionIDs []int) error {
+	_, err := scheduleCall[any](p, auth, http.MethodPost, "schedule/archiveActions", map[string]interface{}{"actionIds": actionIDs}, "archiving actions")
+	return err
+}
+
+// ScheduleCancelActions - cancel the given actions. Actions already picked up by a system are failed on that system
+//
+// param: auth
+// param: actionIDs
+// return: error
+func (p *Proxy) ScheduleCancelActions(auth AuthParams, actionIDs []int) error {
+	_, err := scheduleCall[any](p, auth, http.MethodPost, "schedule/cancelActions", map[string]interface{}{"actionIds": actionIDs}, "canceling actions")
+	return err
+}
+
+// ScheduleDeleteActions - delete the given archived or completed actions
+//
+// param: auth
+// param: actionIDs
+// return: error
+func (p *Proxy) ScheduleDeleteActions(auth AuthParams, actionIDs []int) error {
+	_, err := scheduleCall[any](p, auth, http.MethodPost, "schedule/deleteActions", map[string]interface{}{"actionIds": actionIDs}, "deleting actions")
+	return err
+}
+
+// ScheduleFailSystemAction - fail the given action on the given system
+//
+// param: auth
+// param: systemID
+// param: actionID
+// param: message
+// return: error
+func (p *Proxy) ScheduleFailSystemAction(auth AuthParams, systemID int, actionID int, message string) error {
+	_, err := scheduleCall[any](p, auth, http.MethodPost, "schedule/failSystemAction", map[string]interface{}{"sid": systemID, "actionId": actionID, "message": message}, "failing system action")
+	return err
+}
+
+// ScheduleListAllActions - list all actions, except archived actions
+//
+// param: auth
+// return: []sumamodels.ScheduleAction, error
+func (p *Proxy) ScheduleListAllActions(auth AuthParams) ([]sumamodels.ScheduleAction, error) {
+	return scheduleCall[[]sumamodels.ScheduleAction](p, auth, http.MethodGet, "schedule/listAllActions", nil, "listing actions")
+}
+
+// ScheduleListArchivedActions - list the archived actions
+//
+// param: auth
+// return: []sumamodels.ScheduleAction, error
+func (p *Proxy) ScheduleListArchivedActions(auth AuthParams) ([]sumamodels.ScheduleAction, error) {
+	return scheduleCall[[]sumamodels.ScheduleAction](p, auth, http.MethodGet, "schedule/listArchivedActions", nil, "listing actions")
+}
+
+// ScheduleListCompletedActions - list the actions completed on all their systems
+//
+// param: auth
+// return: []sumamodels.ScheduleAction, error
+func (p *Proxy) ScheduleListCompletedActions(auth AuthParams) ([]sumamodels.ScheduleAction, error) {
+	return scheduleCall[[]sumamodels.ScheduleAction](p, auth, http.MethodGet, "schedule/listCompletedActions", nil, "listing actions")
+}
+
+// ScheduleListFailedActions - list the actions failed on one or more systems
+//
+// param: auth
+// return: []sumamodels.ScheduleAction, error
+func (p *Proxy) ScheduleListFailedActions(auth AuthParams) ([]sumamodels.ScheduleAction, error) {
+	return scheduleCall[[]sumamodels.ScheduleAction](p, auth, http.MethodGet, "schedule/listFailedActions", nil, "listing actions")
+}
+
+// ScheduleListInProgressActions - list the actions in progress on one or more systems
+//
+// param: auth
+// return: []sumamodels.ScheduleAction, error
+func (p *Proxy) ScheduleListInProgressActions(auth AuthParams) ([]sumamodels.ScheduleAction, error) {
+	return scheduleCall[[]sumamodels.ScheduleAction](p, auth, http.MethodGet, "schedule/listInProgressActions", nil, "listing actions")
+}
+
+// ScheduleListCompletedSystems - list the systems that completed the given action
+//
+// param: auth
+// param: actionID
+// return: []sumamodels.ScheduleActionSystem, error
+func (p *Proxy) ScheduleListCompletedSystems(auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error) {
+	return scheduleCall[[]sumamodels.ScheduleActionSystem](p, auth, http.MethodGet, "schedule/listCompletedSystems", map[string]interface{}{"actionId": actionID}, "listing action systems")
+}
+
+// ScheduleListFailedSystems - list the systems that failed the given action, with the failure message
+//
+// param: auth
+// param: actionID
+// return: []sumamodels.ScheduleActionSystem, error
+func (p *Proxy) ScheduleListFailedSystems(auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error) {
+	return scheduleCall[[]sumamodels.ScheduleActionSystem](p, auth, http.MethodGet, "schedule/listFailedSystems", map[string]interface{}{"actionId": actionID}, "listing action systems")
+}
+
+// ScheduleListInProgressSystems - list the systems on which the given action is in progress
+//
+// param: auth
+// param: actionID
+// return: []sumamodels.ScheduleActionSystem, error
+func (p *Proxy) ScheduleListInProgressSystems(auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error) {
+	return scheduleCall[[]sumamodels.ScheduleActionSystem](p, auth, http.MethodGet, "schedule/listInProgressSystems", map[string]interface{}{"actionId": actionID}, "listing action systems")
+}
+
+// ScheduleRescheduleActions - reschedule the given actions, on the failed systems only or on all systems
+//
+// param: auth
+// param: actionIDs
+// param: onlyFailed
+// return: error
+func (p *Proxy) ScheduleRescheduleActions(auth AuthParams, actionIDs []int, onlyFailed bool) error {
+	_, err := scheduleCall[any](p, auth, http.MethodPost, "schedule/rescheduleActions", map[string]interface{}{"actionIds": actionIDs, "onlyFailed": onlyFailed}, "rescheduling actions")
+	return err
+}
+
+// scheduleCall - call the given schedule api path and decode its result
+//
+// param: p
+// param: auth
+// param: method
+// param: path
+// param: params
+// param: what
+// return: T, error
+func scheduleCall[T any](p *Proxy, auth AuthParams, method string, path string, params map[string]interface{}, what string) (T, error) {
+	var result T
+	var body []byte
+	if params != nil {
+		var err error
+		body, err = json.Marshal(params)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return result, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+	}
+	response, err := p.suse.SuseManagerCall(body, method, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return result, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	if response.StatusCode != 200 {
+		log.Error(fmt.Sprintf("%v Failed. Http StatusCode: %v Http Response body: %v", what, response.StatusCode, string(response.Body)))
+		return result, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	resp, err := HandleSuseManagerResponse(response.Body)
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+		return result, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+	}
+	log.Debug(fmt.Sprintf("%v result: %v", path, resp))
+	byteArray, err := json.Marshal(resp)
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return result, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	err = json.Unmarshal(byteArray, &result)
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+		return result, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+	}
+	return result, nil
+}
@@ -2,6 +2,9 @@
 package susemanager
 
 import (
+	"context"
+	"time"
+
 	sumamodels "mlmtool/pkg/models/susemanager"
 	"mlmtool/pkg/util/rest"
 )
@@ -15,12 +18,15 @@ type ISuseManager interface {
 	//ChangeChannels(auth AuthParams, systemID int, targetedVersion string) error
 	//GetHost(negName string, sessionKey string) (*AuthParams, error)
 	//InstallPackages(auth AuthParams, systemID int, pkgs []string, timeout int) error
+	ActiveGroupSystems(ctx context.Context, auth AuthParams, group string) ([]sumamodels.SystemGroupListSystemsMinimal, error)
 	GetAuth(sessionkey string) (*AuthParams, error)
+	OrderedEnvironments(ctx context.Context, auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementEnvironmentList, error)
+	UpdateSystem(ctx context.Context, auth AuthParams, systemID int, timeout int) error
 }
 
 // ISuseManagerAPI - description
 type ISuseManagerAPI interface {
-	SuseManagerCall(body []byte, method string, hostname string, path string, sessionKey string) (output *rest.HTTPHelperStruct, er error)
+	SuseManagerCall(ctx context.Context, body []byte, method string, hostname string, path string, sessionKey string) (output *rest.HTTPHelperStruct, er error)
 }
 
 type (
@@ -37,10 +43,11 @@ type (
 		contentTypeHeader map[string]string
 		suse              ISuseManagerAPI
 		retrycount        int
+		sessions          *SessionManager
 	}
 )
 
-// NewProxy - ope new connection
+// NewProxy - ope new connection, the session is not cached between invocations
 //
 // param: s
 // param: suse
@@ -48,111 +55,185 @@ type (
 // param: retrycount
 // return:
 func NewProxy(s *SumanConfig, suse ISuseManagerAPI, retrycount int) IProxy {
+	return NewProxySessions(s, NewSessionManager(s, suse, "", 0), retrycount)
+}
+
+// NewProxySessions - open new connection, sending all calls through the given session manager
+//
+// param: s
+// param: sessions
+// param: retrycount
+// return:
+func NewProxySessions(s *SumanConfig, sessions *SessionManager, retrycount int) IProxy {
 	header := make(map[string]string)
 	header["Content-Type"] = "application/json"
 	return &Proxy{
 		cfg:               s,
 		contentTypeHeader: header,
-		suse:              suse,
+		suse:              sessions,
 		retrycount:        retrycount,
+		sessions:          sessions,
 	}
 }
 
 // IProxy - all public interface here for the model
 type IProxy interface {
 	// activationkey
-	ActivationKeyAddChildChannels(auth AuthParams, keyName string, childChannels []string) (int, error)
-	ActivationKeyAddServerGroups(auth AuthParams, keyName string, groups []int) (int, error)
-	ActivationKeyCreate(auth AuthParams, keyName string, baseChannel string, entitlement []string) (string, error)
-	ActivationKeyDelete(auth AuthParams, keyName string) (int, error)
-	ActivationKeyGetDetails(auth AuthParams, keyName string) (sumamodels.ActivationkeyGetDetails, error)
-	ActivationKeyListActivationKeys(auth AuthParams) ([]sumamodels.ActivationkeyGetDetails, error)
-	ActivationKeyRemovePackages(auth AuthParams, keyName string, pckgs []sumamodels.ActivationkeyPackages) (int, error)
+	ActivationKeyAddChildChannels(ctx context.Context, auth AuthParams, keyName string, childChannels []string) (int, error)
+	ActivationKeyAddServerGroups(ctx context.Context, auth AuthParams, keyName string, groups []int) (int, error)
+	ActivationKeyCreate(ctx context.Context, auth AuthParams, keyName string, baseChannel string, entitlement []string) (string, error)
+	ActivationKeyDelete(ctx context.Context, auth AuthParams, keyName string) (int, error)
+	ActivationKeyGetDetails(ctx context.Context, auth AuthParams, keyName string) (sumamodels.ActivationkeyGetDetails, error)
+	ActivationKeyListActivationKeys(ctx context.Context, auth AuthParams) ([]sumamodels.ActivationkeyGetDetails, error)
+	ActivationKeyRemovePackages(ctx context.Context, auth AuthParams, keyName string, pckgs []sumamodels.ActivationkeyPackages) (int, error)
+
+	// audit
+	AuditListSystemsByPatchStatus(ctx context.Context, auth AuthParams, cveIdentifier string) ([]sumamodels.AuditSystem, error)
 
 	// authentication
-	CheckResponseProgress(auth AuthParams, response *rest.HTTPHelperStruct, timeOut int, systemID int, funcName string) error
-	GetSessionKey(body []byte, host string) (string, error)
-	SumanLogin() (string, error)
-	SumanLogout(auth AuthParams) error
+	CheckResponseProgress(ctx context.Context, auth AuthParams, response *rest.HTTPHelperStruct, timeOut int, systemID int, funcName string) error
+	GetSessionKey(ctx context.Context, body []byte, host string) (string, error)
+	SumanLogin(ctx context.Context) (string, error)
+	SumanLogout(ctx context.Context, auth AuthParams) error
+	SumanLogoutAll(ctx context.Context)
 
 	// configchannel
-	ConfigChannelListGlobals(auth AuthParams) ([]sumamodels.ConfigChannelListGlobals, error)
+	ConfigChannelListGlobals(ctx context.Context, auth AuthParams) ([]sumamodels.ConfigChannelListGlobals, error)
 
 	// contentmanagement
-	ContentManagementAttachFilter(auth AuthParams, projectLabel string, filterID int) (sumamodels.ContentManagementFilter, error)
-	ContentManagementAttachSource(auth AuthParams, projectLabel string, sourceType string, sourceLabel string) (sumamodels.ContentManagementSource, error)
-	ContentManagementBuildProject(auth AuthParams, projectLabel string) (int, error)
-	ContentManagementCreate(auth AuthParams, projectLabel string, name string, description string) (sumamodels.ContentManagementListProjects, error)
-	ContentManagementCreateEnvironment(auth AuthParams, projectLabel string, predecessorLabel string, envlabel string, name string, description string) (sumamodels.ContentManagementEnvironmentCreate, error)
-	ContentManagementCreateFilter(auth AuthParams, name string, rule string, entityType string, criteria sumamodels.FilterCriteria) (sumamodels.ContentManagementFilter, error)
-	ContentManagementDetachSource(auth AuthParams, projectLabel string, sourceType string, sourceLabel string) error
-	ContentManagementListEnvironments(auth AuthParams, label string) ([]sumamodels.ContentManagementEnvironmentList, error)
-	ContentManagementListFilters(auth AuthParams) ([]sumamodels.ContentManagementFilter, error)
-	ContentManagementListProjects(auth AuthParams) ([]sumamodels.ContentManagementListProjects, error)
-	ContentManagementLookupEnvironment(auth AuthParams, project string, env string) (sumamodels.ContentManagementEnvironmentList, error)
-	ContentManagementLookupProject(auth AuthParams, project string) (sumamodels.ContentManagementListProjects, error)
-	ContentManagementPromoteProject(auth AuthParams, projectLabel string, env string) (int, error)
+	ContentManagementAttachFilter(ctx context.Context, auth AuthParams, projectLabel string, filterID int) (sumamodels.ContentManagementFilter, error)
+	ContentManagementAttachSource(ctx context.Context, auth AuthParams, projectLabel string, sourceType string, sourceLabel string) (sumamodels.ContentManagementSource, error)
+	ContentManagementBuildProject(ctx context.Context, auth AuthParams, projectLabel string) (int, error)
+	ContentManagementCreate(ctx context.Context, auth AuthParams, projectLabel string, name string, description string) (sumamodels.ContentManagementListProjects, error)
+	ContentManagementCreateEnvironment(ctx context.Context, auth AuthParams, projectLabel string, predecessorLabel string, envlabel string, name string, description string) (sumamodels.ContentManagementEnvironmentCreate, error)
+	ContentManagementCreateFilter(ctx context.Context, auth AuthParams, name string, rule string, entityType string, criteria sumamodels.FilterCriteria) (sumamodels.ContentManagementFilter, error)
+	ContentManagementDetachFilter(ctx context.Context, auth AuthParams, projectLabel string, filterID int) error
+	ContentManagementDetachSource(ctx context.Context, auth AuthParams, projectLabel string, sourceType string, sourceLabel string) error
+	ContentManagementListEnvironments(ctx context.Context, auth AuthParams, label string) ([]sumamodels.ContentManagementEnvironmentList, error)
+	ContentManagementListFilters(ctx context.Context, auth AuthParams) ([]sumamodels.ContentManagementFilter, error)
+	ContentManagementListProjectFilters(ctx context.Context, auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementProjectFilter, error)
+	ContentManagementListProjectSources(ctx context.Context, auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementSource, error)
+	ContentManagementListProjects(ctx context.Context, auth AuthParams) ([]sumamodels.ContentManagementListProjects, error)
+	ContentManagementLookupEnvironment(ctx context.Context, auth AuthParams, project string, env string) (sumamodels.ContentManagementEnvironmentList, error)
+	ContentManagementLookupProject(ctx context.Context, auth AuthParams, project string) (sumamodels.ContentManagementListProjects, error)
+	ContentManagementPromoteProject(ctx context.Context, auth AuthParams, projectLabel string, env string) (int, error)
+	ContentManagementRemoveEnvironment(ctx context.Context, auth AuthParams, projectLabel string, envLabel string) (int, error)
+	ContentManagementRemoveProject(ctx context.Context, auth AuthParams, projectLabel string) (int, error)
+
+	// errata
+	ErrataApplicableToChannels(ctx context.Context, auth AuthParams, advisoryName string) ([]sumamodels.ErrataChannel, error)
+
+	// image
+	ImageListImages(ctx context.Context, auth AuthParams) ([]sumamodels.ImageOverview, error)
+	ImageProfileCreate(ctx context.Context, auth AuthParams, label string, imageType string, storeLabel string, imagePath string, activationKey string, kiwiOptions string) error
+	ImageProfileDelete(ctx context.Context, auth AuthParams, label string) error
+	ImageProfileGetDetails(ctx context.Context, auth AuthParams, label string) (sumamodels.ImageProfile, error)
+	ImageProfileListImageProfiles(ctx context.Context, auth AuthParams) ([]sumamodels.ImageProfile, error)
+	ImageScheduleImageBuild(ctx context.Context, auth AuthParams, profileLabel string, version string, buildHostID int) (int, error)
+	ImageStoreListImageStores(ctx context.Context, auth AuthParams) ([]sumamodels.ImageStore, error)
 
 	// system
-	CheckProgress(auth AuthParams, actionID int, timeout int, action string, systemID int) (int, error)
-	ListCompleteSystem(auth AuthParams, actionID int) ([]interface{}, error)
-	ListInprogressSystem(auth AuthParams, actionID int) ([]interface{}, error)
-	ListLatestInstallablePackages(auth AuthParams, systemID int) ([]sumamodels.InstallablePackage, error)
-	SchedulePackageRefresh(auth AuthParams, systemID int) error
-	ScheduleScriptRun(auth AuthParams, systemID int, timeout int, script string) error
-	SystemGetID(auth AuthParams, systemName string) ([]sumamodels.System, error)
-	SystemGetScriptResult(auth AuthParams, actionID int, resultCompleted int) (string, error)
-	SystemGetSubscribedBaseChannel(auth AuthParams, systemID int) (sumamodels.SubscribedBaseChannel, error)
-	SystemListActiveSystems(auth AuthParams) ([]sumamodels.ActiveSystem, error)
-	SystemListInstalledPackages(auth AuthParams, systemID int) ([]sumamodels.InstalledPackage, error)
-	SystemScheduleApplyHighstate(auth AuthParams, systemID int, timeout int) error
-	SystemScheduleApplyStates(auth AuthParams, systemID int, stateNames []string, timeout int) error
-	SystemScheduleChangeChannels(auth AuthParams, systemID int, basechannel string, childChannel []sumamodels.ChannelSoftwareListChildren) error
-	SystemScheduleReboot(auth AuthParams, systemID int, timeout int) error
+	CheckProgress(ctx context.Context, auth AuthParams, actionID int, timeout int, action string, systemID int) (int, error)
+	ListLatestInstallablePackages(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.InstallablePackage, error)
+	SchedulePackageRefresh(ctx context.Context, auth AuthParams, systemID int) error
+	ScheduleScriptRun(ctx context.Context, auth AuthParams, systemID int, timeout int, script string) error
+	SystemGetID(ctx context.Context, auth AuthParams, systemName string) ([]sumamodels.System, error)
+	SystemGetRelevantErrata(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.Errata, error)
+	SystemGetScriptResult(ctx context.Context, auth AuthParams, actionID int, resultCompleted int) (string, error)
+	SystemGetSubscribedBaseChannel(ctx context.Context, auth AuthParams, systemID int) (sumamodels.SubscribedBaseChannel, error)
+	SystemListActiveSystems(ctx context.Context, auth AuthParams) ([]sumamodels.ActiveSystem, error)
+	SystemListInstalledPackages(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.InstalledPackage, error)
+	SystemListLatestUpgradablePackages(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.UpgradablePackage, error)
+	SystemListMigrationTargets(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.MigrationTarget, error)
+	SystemListSuggestedReboot(ctx context.Context, auth AuthParams) ([]sumamodels.System, error)
+	SystemListSystemEvents(ctx context.Context, auth AuthParams, systemID int, earliestDate time.Time) ([]sumamodels.SystemEvent, error)
+	SystemListSystems(ctx context.Context, auth AuthParams) ([]sumamodels.System, error)
+	SystemObtainReactivationKey(ctx context.Context, auth AuthParams, systemID int) (string, error)
+	SystemGetConnectionPath(ctx context.Context, auth AuthParams, systemID int) ([]sumamodels.SystemConnectionPath, error)
+	SystemScheduleApplyErrata(ctx context.Context, auth AuthParams, systemID int, errataIDs []int) ([]int, error)
+	SystemScheduleApplyHighstate(ctx context.Context, auth AuthParams, systemID int, timeout int) error
+	SystemScheduleApplyStates(ctx context.Context, auth AuthParams, systemID int, stateNames []string, timeout int) error
+	SystemScheduleChangeChannels(ctx context.Context, auth AuthParams, systemID int, basechannel string, childChannel []sumamodels.ChannelSoftwareListChildren) error
+	SystemScheduleHighstate(ctx context.Context, auth AuthParams, systemID int, test bool) (int, error)
+	SystemSchedulePackageInstall(ctx context.Context, auth AuthParams, systemID int, packageIDs []int) (int, error)
+	SystemScheduleProductMigration(ctx context.Context, auth AuthParams, systemID int, targetIdent string, baseChannel string, childChannels []string, dryRun bool, timeout int) error
+	SystemScheduleReboot(ctx context.Context, auth AuthParams, systemID int, timeout int) error
+	SystemScheduleRebootAction(ctx context.Context, auth AuthParams, systemID int) (int, error)
+
+	// proxy
+	ProxyListProxyClients(ctx context.Context, auth AuthParams, proxyID int) ([]int, error)
+
+	// schedule
+	ScheduleArchiveActions(ctx context.Context, auth AuthParams, actionIDs []int) error
+	ScheduleCancelActions(ctx context.Context, auth AuthParams, actionIDs []int) error
+	ScheduleDeleteActions(ctx context.Context, auth AuthParams, actionIDs []int) error
+	ScheduleFailSystemAction(ctx context.Context, auth AuthParams, systemID int, actionID int, message string) error
+	ScheduleListAllActions(ctx context.Context, auth AuthParams) ([]sumamodels.ScheduleAction, error)
+	ScheduleListArchivedActions(ctx context.Context, auth AuthParams) ([]sumamodels.ScheduleAction, error)
+	ScheduleListCompletedActions(ctx context.Context, auth AuthParams) ([]sumamodels.ScheduleAction, error)
+	ScheduleListCompletedSystems(ctx context.Context, auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error)
+	ScheduleListFailedActions(ctx context.Context, auth AuthParams) ([]sumamodels.ScheduleAction, error)
+	ScheduleListFailedSystems(ctx context.Context, auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error)
+	ScheduleListInProgressActions(ctx context.Context, auth AuthParams) ([]sumamodels.ScheduleAction, error)
+	ScheduleListInProgressSystems(ctx context.Context, auth AuthParams, actionID int) ([]sumamodels.ScheduleActionSystem, error)
+	ScheduleRescheduleActions(ctx context.Context, auth AuthParams, actionIDs []int, onlyFailed bool) error
 
 	// sync
-	GetSlaves(sessionKey string) ([]sumamodels.Slaves, error)
-	SyncMasterCreate(auth AuthParams, masterFQDN string) (sumamodels.SlavesIssMaster, error)
-	SyncMasterDelete(auth AuthParams, masterID int) (int, error)
-	SyncMasterGetMasterByLabel(auth AuthParams, slaveFQDN string) (sumamodels.SlavesIssMaster, error)
-	SyncMasterMakeDefault(auth AuthParams, masterID int) (int, error)
-	SyncMasterSetCaCert(auth AuthParams, masterID int, caCert string) (int, error)
-	SyncSlaveCreate(auth AuthParams, slaveFQDN string, isEnabled bool, allowAllOrgs bool) (sumamodels.Slaves, error)
-	SyncSlaveDelete(auth AuthParams, slaveID int) (int, error)
-	SyncSlaveGetSlaveByName(auth AuthParams, slaveFQDN string) (sumamodels.Slaves, error)
+	GetSlaves(ctx context.Context, sessionKey string) ([]sumamodels.Slaves, error)
+	SyncMasterCreate(ctx context.Context, auth AuthParams, masterFQDN string) (sumamodels.SlavesIssMaster, error)
+	SyncMasterDelete(ctx context.Context, auth AuthParams, masterID int) (int, error)
+	SyncMasterGetMasterByLabel(ctx context.Context, auth AuthParams, slaveFQDN string) (sumamodels.SlavesIssMaster, error)
+	SyncMasterMakeDefault(ctx context.Context, auth AuthParams, masterID int) (int, error)
+	SyncMasterSetCaCert(ctx context.Context, auth AuthParams, masterID int, caCert string) (int, error)
+	SyncSlaveCreate(ctx context.Context, auth AuthParams, slaveFQDN string, isEnabled bool, allowAllOrgs bool) (sumamodels.Slaves, error)
+	SyncSlaveDelete(ctx context.Context, auth AuthParams, slaveID int) (int, error)
+	SyncSlaveGetSlaveByName(ctx context.Context, auth AuthParams, slaveFQDN string) (sumamodels.Slaves, error)
 
 	// Channels
-	ChannelListSoftwareChannels(auth AuthParams) ([]sumamodels.ChannelListSoftwareChannels, error)
-	ChannelSoftwareAssociateRepo(auth AuthParams, channelLabel string, repoLabel string) (sumamodels.ChannelSoftwareListChildren, error)
-	ChannelSoftwareCreate(auth AuthParams, label string, name string, summary string, archLabel string, parentLabel string) (int, error)
-	ChannelSoftwareCreateRepo(auth AuthParams, label string, typeRepo string, url string) (sumamodels.ChannelSoftwareCreateRepo, error)
-	ChannelSoftwareIsExisting(auth AuthParams, label string) (bool, error)
-	ChannelSoftwareListChildren(auth AuthParams, label string) ([]sumamodels.ChannelSoftwareListChildren, error)
-	ChannelSoftwareSyncRepo(auth AuthParams, channelLabel string) (int, error)
+	ChannelListSoftwareChannels(ctx context.Context, auth AuthParams) ([]sumamodels.ChannelListSoftwareChannels, error)
+	ChannelSoftwareAssociateRepo(ctx context.Context, auth AuthParams, channelLabel string, repoLabel string) (sumamodels.ChannelSoftwareListChildren, error)
+	ChannelSoftwareCreate(ctx context.Context, auth AuthParams, label string, name string, summary string, archLabel string, parentLabel string) (int, error)
+	ChannelSoftwareCreateRepo(ctx context.Context, auth AuthParams, label string, typeRepo string, url string, sslCaCert string, sslCliCert string, sslCliKey string) (sumamodels.ChannelSoftwareCreateRepo, error)
+	ChannelSoftwareDelete(ctx context.Context, auth AuthParams, label string) error
+	ChannelSoftwareDisassociateRepo(ctx context.Context, auth AuthParams, channelLabel string, repoLabel string) error
+	ChannelSoftwareGetDetails(ctx context.Context, auth AuthParams, label string) (sumamodels.ChannelSoftwareListChildren, error)
+	ChannelSoftwareGetDetailsByID(ctx context.Context, auth AuthParams, channelID int) (sumamodels.ChannelSoftwareListChildren, error)
+	ChannelSoftwareIsExisting(ctx context.Context, auth AuthParams, label string) (bool, error)
+	ChannelSoftwareListChildren(ctx context.Context, auth AuthParams, label string) ([]sumamodels.ChannelSoftwareListChildren, error)
+	ChannelSoftwareListSubscribedSystems(ctx context.Context, auth AuthParams, label string) ([]sumamodels.ChannelSoftwareSubscribedSystem, error)
+	ChannelSoftwareListUserRepos(ctx context.Context, auth AuthParams) ([]sumamodels.ChannelSoftwareCreateRepo, error)
+	ChannelSoftwareRemoveRepo(ctx context.Context, auth AuthParams, label string) error
+	ChannelSoftwareSetDetails(ctx context.Context, auth AuthParams, channelLabel string, details map[string]interface{}) error
+	ChannelSoftwareSyncRepo(ctx context.Context, auth AuthParams, channelLabel string) (int, error)
+	ChannelSoftwareUpdateRepoURL(ctx context.Context, auth AuthParams, label string, url string) error
 	//	SystemGetSubscribedBaseChannel(auth AuthParams, systemID int) (*sumamodels.SubscribedChannel, error)
 
 	// Add func for formula
-	FormulaSetFormulasOfGroup(auth AuthParams, systemID int, formulaNames []string) (int, error)
-	FormulaSetFormulasOfSystem(auth AuthParams, systemID int, formulaNames []string) (int, error)
-	GetFormulasByGroupID(auth AuthParams, groupID int) ([]string, error)
-	GetFormulasByServerID(auth AuthParams, systemID int) ([]string, error)
-	GetGroupFormulaData(auth AuthParams, groupID int, formulaName string) (interface{}, error)
-	GetSystemFormulaData(auth AuthParams, sid int, formulaname string) (interface{}, error)
-	SetGroupFormulaData(auth AuthParams, groupID int, formulaName string, formulaData interface{}) (int, error)
-	SetSystemFormulaData(auth AuthParams, systemID int, formulaName string, formulaData interface{}) (int, error)
+	FormulaSetFormulasOfGroup(ctx context.Context, auth AuthParams, systemID int, formulaNames []string) (int, error)
+	FormulaSetFormulasOfSystem(ctx context.Context, auth AuthParams, systemID int, formulaNames []string) (int, error)
+	GetFormulasByGroupID(ctx context.Context, auth AuthParams, groupID int) ([]string, error)
+	GetFormulasByServerID(ctx context.Context, auth AuthParams, systemID int) ([]string, error)
+	GetGroupFormulaData(ctx context.Context, auth AuthParams, groupID int, formulaName string) (interface{}, error)
+	GetSystemFormulaData(ctx context.Context, auth AuthParams, sid int, formulaname string) (interface{}, error)
+	SetGroupFormulaData(ctx context.Context, auth AuthParams, groupID int, formulaName string, formulaData interface{}) (int, error)
+	SetSystemFormulaData(ctx context.Context, auth AuthParams, systemID int, formulaName string, formulaData interface{}) (int, error)
 
 	// SystemGroup
-	SystemGroupCreate(auth AuthParams, groupName string, description string) (*sumamodels.SystemGroupGetDetails, error)
-	SystemGroupGetDetails(auth AuthParams, groupName string) (*sumamodels.SystemGroupGetDetails, error)
-	SystemGroupListActiveSystemsInGroup(auth AuthParams, groupName string) ([]int, error)
-	SystemGroupListSystemsMinimal(auth AuthParams, groupName string) ([]sumamodels.SystemGroupListSystemsMinimal, error)
+	SystemGroupAddOrRemoveSystems(ctx context.Context, auth AuthParams, groupName string, systemIDs []int, add bool) error
+	SystemGroupCreate(ctx context.Context, auth AuthParams, groupName string, description string) (*sumamodels.SystemGroupGetDetails, error)
+	SystemGroupDelete(ctx context.Context, auth AuthParams, groupName string) error
+	SystemGroupGetDetails(ctx context.Context, auth AuthParams, groupName string) (*sumamodels.SystemGroupGetDetails, error)
+	SystemGroupListActiveSystemsInGroup(ctx context.Context, auth AuthParams, groupName string) ([]int, error)
+	SystemGroupListAllGroups(ctx context.Context, auth AuthParams) ([]sumamodels.SystemGroupGetDetails, error)
+	SystemGroupListSystemsMinimal(ctx context.Context, auth AuthParams, groupName string) ([]sumamodels.SystemGroupListSystemsMinimal, error)
 
 	// KickstartTree
-	KickstartDeleteProfile(auth AuthParams, profileName string) (int, error)
-	KickstartImportRawFile(auth AuthParams, profileLabel string, virtType string, channelLabel string, dataXML string) (int, error)
-	KickstartListKickstarts(auth AuthParams) ([]sumamodels.KickstartListProfiles, error)
-	KickstartProfileSetVariables(auth AuthParams, profileLabel string, profileVariables interface{}) (int, error)
-	KickstartTreeCreate(auth AuthParams, treeLabel string, basePath string, channelLabel string, installType string) (int, error)
-	KickstartTreeCreateKernelOptions(auth AuthParams, treeLabel string, basePath string, channelLabel string, installType string, kernelOptions string, postKernelOptions string) (int, error)
-	KickstartTreeGetDetails(auth AuthParams, distributionName string) (sumamodels.KickstartTreeGetDetails, error)
+	KickstartDeleteProfile(ctx context.Context, auth AuthParams, profileName string) (int, error)
+	KickstartImportRawFile(ctx context.Context, auth AuthParams, profileLabel string, virtType string, channelLabel string, dataXML string) (int, error)
+	KickstartListKickstarts(ctx context.Context, auth AuthParams) ([]sumamodels.KickstartListProfiles, error)
+	KickstartProfileSetVariables(ctx context.Context, auth AuthParams, profileLabel string, profileVariables interface{}) (int, error)
+	KickstartRenameProfile(ctx context.Context, auth AuthParams, originalLabel string, newLabel string) (int, error)
+	KickstartTreeCreate(ctx context.Context, auth AuthParams, treeLabel string, basePath string, channelLabel string, installType string) (int, error)
+	KickstartTreeCreateKernelOptions(ctx context.Context, auth AuthParams, treeLabel string, basePath string, channelLabel string, installType string, kernelOptions string, postKernelOptions string) (int, error)
+	KickstartTreeGetDetails(ctx context.Context, auth AuthParams, distributionName string) (sumamodels.KickstartTreeGetDetails, error)
 }
@@ -0,0 +1,373 @@
+// Package susemanager - SUSE Manager api call and support functions
+package susemanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "mlmtool/pkg/util/logger"
+
+	"go.uber.org/zap"
+
+	"mlmtool/pkg/util/rest"
+)
+
+const (
+	// SessionCookie - name of the cookie holding the session of the api
+	SessionCookie = "pxt-session-cookie"
+	// DefaultSessionTTL - time a cached session is reused, below the default session lifetime of the server
+	DefaultSessionTTL = 30 * time.Minute
+)
+
+// sessionCacheMu - serializes the access to the session cache files of all session managers of the process
+var sessionCacheMu sync.Mutex
+
+// cachedSession - session stored in the session cache file
+type cachedSession struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+// SessionManager - keeps one session per server and user. All proxy calls are sent through it: the session key handed
+// out by Session is replaced by the current one, and a call failing with an expired session is repeated once after a
+// new login. With a cache file the session is stored between invocations until its ttl passed, otherwise it is
+// logged out by Close. Safe for concurrent use.
+type SessionManager struct {
+	api     ISuseManagerAPI
+	cfg     *SumanConfig
+	cache   string
+	ttl     time.Duration
+	mu      sync.Mutex
+	key     string
+	expires time.Time
+	issued  map[string]bool
+}
+
+// NewSessionManager - create a session manager for the server and user of cfg. cache is the path of the session cache
+// file, empty to disable caching. ttl 0 uses DefaultSessionTTL.
+//
+// param: cfg
+// param: api
+// param: cache
+// param: ttl
+// return: *SessionManager
+func NewSessionManager(cfg *SumanConfig, api ISuseManagerAPI, cache string, ttl time.Duration) *SessionManager {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &SessionManager{
+		api:    api,
+		cfg:    cfg,
+		cache:  cache,
+		ttl:    ttl,
+		issued: make(map[string]bool),
+	}
+}
+
+// Session - key of the current session. A cached session is reused while its ttl has not passed, otherwise a new
+// session is opened.
+//
+// return: string, error
+func (m *SessionManager) Session() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.key) > 0 {
+		return m.key, nil
+	}
+	if cached, ok := m.loadCache(); ok {
+		log.Debug("reusing cached session", zap.Any("host", m.cfg.Host), zap.Any("expires", cached.Expires))
+		m.key = cached.Key
+		m.expires = cached.Expires
+		m.issued[m.key] = true
+		return m.key, nil
+	}
+	return m.login()
+}
+
+// Logout - logout the current session and remove it from the cache
+//
+// return: error
+func (m *SessionManager) Logout() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.key) == 0 {
+		return nil
+	}
+	err := m.logout(m.key)
+	m.key = ""
+	m.storeCache(nil)
+	return err
+}
+
+// Close - end the use of the session. With a cache file the session is kept for the next invocation, otherwise it is
+// logged out.
+func (m *SessionManager) Close() {
+	m.mu.Lock()
+	cached := len(m.cache) > 0 && len(m.key) > 0
+	m.mu.Unlock()
+	if cached {
+		log.Debug("session kept in cache", zap.Any("host", m.cfg.Host), zap.Any("cache", m.cache))
+		return
+	}
+	_ = m.Logout()
+}
+
+// Owns - check if the session key was handed out by the session manager
+//
+// param: sessionKey
+// return: bool
+func (m *SessionManager) Owns(sessionKey string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.issued[sessionKey]
+}
+
+// Context - context of all calls
+//
+// return: context.Context
+func (m *SessionManager) Context() context.Context {
+	return m.api.Context()
+}
+
+// SuseManagerCall - Call api at SUSE Manager with the current session
+//
+// param: body
+// param: method
+// param: hostname
+// param: path
+// param: sessionKey
+// return:
+func (m *SessionManager) SuseManagerCall(body []byte, method string, hostname string, path string, sessionKey string) (*rest.HTTPHelperStruct, error) {
+	return m.SuseManagerCallContext(m.api.Context(), body, method, hostname, path, sessionKey)
+}
+
+// SuseManagerCallContext - Call api at SUSE Manager with the current session, logging in again when the session
+// expired. Calls with a session key not handed out by the session manager are passed on unchanged.
+//
+// param: ctx
+// param: body
+// param: method
+// param: hostname
+// param: path
+// param: sessionKey
+// return:
+func (m *SessionManager) SuseManagerCallContext(ctx context.Context, body []byte, method string, hostname string, path string, sessionKey string) (*rest.HTTPHelperStruct, error) {
+	if !m.Owns(sessionKey) {
+		return m.api.SuseManagerCallContext(ctx, body, method, hostname, path, sessionKey)
+	}
+	m.mu.Lock()
+	key := m.key
+	m.mu.Unlock()
+	if len(key) == 0 {
+		// logged out in between, open a new session
+		var err error
+		if key, err = m.Session(); err != nil {
+			return nil, err
+		}
+	}
+	response, err := m.api.SuseManagerCallContext(ctx, body, method, hostname, path, key)
+	if err != nil || !sessionExpired(response) {
+		return response, err
+	}
+	log.Info("session expired, logging in again", zap.Any("host", m.cfg.Host), zap.Any("call", path))
+	key, err = m.relogin(key)
+	if err != nil {
+		return nil, err
+	}
+	return m.api.SuseManagerCallContext(ctx, body, method, hostname, path, key)
+}
+
+// relogin - open a new session replacing the expired one, unless another call did so already
+func (m *SessionManager) relogin(expired string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.key) > 0 && m.key != expired {
+		return m.key, nil
+	}
+	return m.login()
+}
+
+// login - open a new session and store it in the cache. Must be called with mu held.
+func (m *SessionManager) login() (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"login":    strings.TrimSpace(m.cfg.Login),
+		"password": strings.TrimSpace(m.cfg.Password)})
+	if err != nil {
+		return "", err
+	}
+	key, err := getSessionKey(m.api, body, m.cfg.Host)
+	if err != nil {
+		return "", err
+	}
+	m.key = key
+	m.expires = time.Now().Add(m.ttl)
+	m.issued[key] = true
+	m.storeCache(&cachedSession{Key: m.key, Expires: m.expires})
+	log.Debug("Successfull login to suse manager", zap.Any("host", m.cfg.Host))
+	return key, nil
+}
+
+// logout - logout the session. The logout is sent even when the context of the api is already cancelled.
+func (m *SessionManager) logout(key string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(m.api.Context()), logoutTimeout)
+	defer cancel()
+	_, err := m.api.SuseManagerCallContext(ctx, nil, http.MethodGet, m.cfg.Host, "auth/logout", key)
+	if err != nil {
+		log.Error("Unable to logout the request from suse manager", zap.Any("error", err))
+		return errors.New("error while logout suse manager")
+	}
+	log.Debug("Successfully logout from SUSE Manager Server", zap.Any("host", m.cfg.Host))
+	return nil
+}
+
+// cacheEntry - key of the session of the server and user in the cache file
+func (m *SessionManager) cacheEntry() string {
+	return fmt.Sprintf("%v@%v", strings.TrimSpace(m.cfg.Login), m.cfg.Host)
+}
+
+// loadCache - cached session of the server and user, if its ttl has not passed. A session found with its ttl passed
+// is logged out and removed. Must be called with mu held.
+func (m *SessionManager) loadCache() (cachedSession, bool) {
+	if len(m.cache) == 0 {
+		return cachedSession{}, false
+	}
+	sessionCacheMu.Lock()
+	sessions := readSessionCache(m.cache)
+	sessionCacheMu.Unlock()
+	cached, ok := sessions[m.cacheEntry()]
+	if !ok {
+		return cachedSession{}, false
+	}
+	if time.Now().Before(cached.Expires) {
+		return cached, true
+	}
+	log.Debug("cached session expired", zap.Any("host", m.cfg.Host))
+	_ = m.logout(cached.Key)
+	m.storeCache(nil)
+	return cachedSession{}, false
+}
+
+// storeCache - store the session of the server and user in the cache file, nil removes it
+func (m *SessionManager) storeCache(session *cachedSession) {
+	if len(m.cache) == 0 {
+		return
+	}
+	sessionCacheMu.Lock()
+	defer sessionCacheMu.Unlock()
+	sessions := readSessionCache(m.cache)
+	if session == nil {
+		delete(sessions, m.cacheEntry())
+	} else {
+		sessions[m.cacheEntry()] = *session
+	}
+	if err := writeSessionCache(m.cache, sessions); err != nil {
+		log.Warn(fmt.Sprintf("unable to write session cache %v: %v", m.cache, err))
+	}
+}
+
+// readSessionCache - sessions of the cache file. A missing, unreadable or not private file is treated as empty.
+func readSessionCache(path string) map[string]cachedSession {
+	sessions := make(map[string]cachedSession)
+	info, err := os.Stat(path)
+	if err != nil {
+		return sessions
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		log.Warn(fmt.Sprintf("session cache %v is accessible by other users, ignoring it", path))
+		return sessions
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		log.Warn(fmt.Sprintf("unable to read session cache %v: %v", path, err))
+		return sessions
+	}
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		log.Warn(fmt.Sprintf("unable to parse session cache %v: %v", path, err))
+		return make(map[string]cachedSession)
+	}
+	return sessions
+}
+
+// writeSessionCache - replace the cache file, readable by the owner only
+func writeSessionCache(path string, sessions map[string]cachedSession) error {
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// getSessionKey - login and return the session cookie of the response as session key
+func getSessionKey(api ISuseManagerAPI, body []byte, host string) (string, error) {
+	response, err := api.SuseManagerCall(body, http.MethodPost, host, "auth/login", "")
+	if err != nil {
+		log.Error("error while login to suse manager", zap.Any("error", err))
+		return "", errors.New("error while login to suse manager")
+	}
+	if _, err := HandleSuseManagerResponse(response.Body); err != nil {
+		log.Error("Unable to retrieve Cookie. Login problem", zap.Any("host", host), zap.Any("Error", err))
+		return "", fmt.Errorf("login to %v failed: %w", host, err)
+	}
+	key, err := sessionCookie(response.Cookies)
+	if err != nil {
+		return "", fmt.Errorf("login to %v failed: %w", host, err)
+	}
+	log.Debug("succesfully retrieved Cookie.", zap.Any("host", host))
+	return key, nil
+}
+
+// sessionCookie - session cookie of a login response. The server also sends an expired session cookie, so the last
+// one still valid is used.
+func sessionCookie(cookies []*http.Cookie) (string, error) {
+	key := ""
+	for _, cookie := range cookies {
+		expired := cookie.MaxAge < 0 || (!cookie.Expires.IsZero() && cookie.Expires.Before(time.Now()))
+		if cookie.Name == SessionCookie && len(cookie.Value) > 0 && !expired {
+			key = fmt.Sprintf("%v=%v", cookie.Name, cookie.Value)
+		}
+	}
+	if len(key) == 0 {
+		return "", fmt.Errorf("no %v in the login response", SessionCookie)
+	}
+	return key, nil
+}
+
+// sessionExpired - check if the call was rejected because the session is not valid anymore
+func sessionExpired(response *rest.HTTPHelperStruct) bool {
+	if response.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if response.StatusCode != http.StatusOK {
+		return false
+	}
+	_, err := HandleSuseManagerResponse(response.Body)
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "could not find session")
+}
@@ -0,0 +1,107 @@
+package susemanager_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/testing/fakesuma"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/rest"
+)
+
+func TestSessionManager(t *testing.T) {
+	tests := []struct {
+		name             string
+		cache            bool
+		ttl              time.Duration
+		between          func(fake *fakesuma.Server, cache string)
+		expectSameKey    bool
+		expectedLogins   int
+		expectedSessions int
+	}{
+		{
+			name:             "session logged out without cache",
+			expectedLogins:   2,
+			expectedSessions: 1,
+		},
+		{
+			name:             "cached session reused",
+			cache:            true,
+			expectSameKey:    true,
+			expectedLogins:   1,
+			expectedSessions: 1,
+		},
+		{
+			name:             "cached session past its ttl replaced",
+			cache:            true,
+			ttl:              time.Nanosecond,
+			expectedLogins:   2,
+			expectedSessions: 1,
+		},
+		{
+			name:  "cached session expired on the server",
+			cache: true,
+			between: func(fake *fakesuma.Server, _ string) {
+				fake.ExpireSessions()
+			},
+			expectSameKey:    true,
+			expectedLogins:   2,
+			expectedSessions: 1,
+		},
+		{
+			name:  "cache readable by others ignored",
+			cache: true,
+			between: func(_ *fakesuma.Server, cache string) {
+				_ = os.Chmod(cache, 0o644)
+			},
+			expectedLogins:   2,
+			expectedSessions: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			cache := ""
+			if tt.cache {
+				cache = filepath.Join(t.TempDir(), "mlmtool", "sessions.json")
+			}
+			newProxy := func() _sumanUseCase.IProxy {
+				client, _ := rest.NewClient(rest.ClientConfig{Insecure: true})
+				suseAPI := _sumanUseCase.NewSuseManagerAPIClient(context.Background(), fakesuma.BasePath, client, true)
+				return _sumanUseCase.NewProxySessions(fake.Config(), _sumanUseCase.NewSessionManager(fake.Config(), suseAPI, cache, tt.ttl), 3)
+			}
+
+			proxy := newProxy()
+			first, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			again, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			assert.Equal(t, first, again)
+			proxy.SumanLogoutAll()
+			if tt.cache {
+				info, err := os.Stat(cache)
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+			}
+			if tt.between != nil {
+				tt.between(fake, cache)
+			}
+
+			proxy = newProxy()
+			second, err := proxy.SumanLogin()
+			assert.NoError(t, err)
+			_, err = proxy.ScheduleListInProgressActions(_sumanUseCase.AuthParams{Host: fake.Host(), SessionKey: second})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectSameKey, first == second)
+			assert.Equal(t, tt.expectedLogins, fake.Calls("auth/login"))
+			assert.Equal(t, tt.expectedSessions, fake.Sessions())
+		})
+	}
+}
@@ -2,9 +2,12 @@
 package susemanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -124,7 +127,7 @@ func (s *SuseManager) ChangeChannels(auth AuthParams, systemID int, targetedVers
 	if err != nil {
 		return fmt.Errorf("error while updating the channels")
 	}
-	logger.Info("Channel change is completed for %v", systemID)
+	logger.Infof("Channel change is completed for %v", systemID)
 
 	return nil
 }
@@ -136,7 +139,7 @@ func (s *SuseManager) ChangeChannels(auth AuthParams, systemID int, targetedVers
 // param: pkgs
 // param: timeout
 func (s *SuseManager) InstallPackages(auth AuthParams, systemID int, pkgs []string, timeout int) error {
-	logger.Debug("Inside Install pkgs function. pkgs: %v", pkgs)
+	logger.Debugf("Inside Install pkgs function. pkgs: %v", pkgs)
 	// Getting Installed Pkgs
 	installedPkgs, err := s.proxy.SystemListInstalledPackages(auth, systemID)
 	if err != nil {
@@ -181,6 +184,138 @@ func (s *SuseManager) InstallPackages(auth AuthParams, systemID int, pkgs []stri
 	return nil
 }
 
+// OrderedEnvironments - list the environments of a project ordered from first to last
+//
+// param: auth
+// param: projectLabel
+// return:
+func (s *SuseManager) OrderedEnvironments(auth AuthParams, projectLabel string) ([]sumamodels.ContentManagementEnvironmentList, error) {
+	environments, err := s.proxy.ContentManagementListEnvironments(auth, projectLabel)
+	if err != nil {
+		return nil, err
+	}
+	byLabel := make(map[string]sumamodels.ContentManagementEnvironmentList)
+	current := ""
+	for _, env := range environments {
+		byLabel[env.Label] = env
+		if len(env.PreviousEnvironmentLabel) == 0 {
+			current = env.Label
+		}
+	}
+	var ordered []sumamodels.ContentManagementEnvironmentList
+	for len(current) > 0 && len(ordered) < len(environments) {
+		env, ok := byLabel[current]
+		if !ok {
+			break
+		}
+		ordered = append(ordered, env)
+		current = env.NextEnvironmentLabel
+	}
+	if len(ordered) != len(environments) {
+		return nil, fmt.Errorf("unable to determine the order of the environments of project %s", projectLabel)
+	}
+	return ordered, nil
+}
+
+// ActiveGroupSystems - list the active systems of a system group
+//
+// param: auth
+// param: group
+// return:
+func (s *SuseManager) ActiveGroupSystems(auth AuthParams, group string) ([]sumamodels.SystemGroupListSystemsMinimal, error) {
+	_, err := s.proxy.SystemGroupGetDetails(auth, group)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrSystemGroupNotFound, err))
+		return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemGroupNotFound, group)
+	}
+	activeIDs, err := s.proxy.SystemGroupListActiveSystemsInGroup(auth, group)
+	if err != nil {
+		return nil, err
+	}
+	systems, err := s.proxy.SystemGroupListSystemsMinimal(auth, group)
+	if err != nil {
+		return nil, err
+	}
+	var active []sumamodels.SystemGroupListSystemsMinimal
+	for _, system := range systems {
+		for _, id := range activeIDs {
+			if system.ID == id {
+				active = append(active, system)
+				break
+			}
+		}
+	}
+	return active, nil
+}
+
+// UpdateSystem - apply all relevant errata and upgrade all remaining packages on a system
+//
+// param: auth
+// param: systemID
+// param: timeout
+func (s *SuseManager) UpdateSystem(auth AuthParams, systemID int, timeout int) error {
+	errata, err := s.proxy.SystemGetRelevantErrata(auth, systemID)
+	if err != nil {
+		return err
+	}
+	if len(errata) > 0 {
+		var errataIDs []int
+		for i := range errata {
+			errataIDs = append(errataIDs, errata[i].ID)
+		}
+		logger.Info(fmt.Sprintf("applying %d errata on system %d", len(errataIDs), systemID))
+		actionIDs, err := s.proxy.SystemScheduleApplyErrata(auth, systemID, errataIDs)
+		if err != nil {
+			return err
+		}
+		for _, actionID := range actionIDs {
+			_, err = s.proxy.CheckProgress(auth, actionID, timeout, "SystemScheduleApplyErrata", systemID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	pkgs, err := s.proxy.SystemListLatestUpgradablePackages(auth, systemID)
+	if err != nil {
+		return err
+	}
+	if len(pkgs) > 0 {
+		var packageIDs []int
+		for i := range pkgs {
+			packageIDs = append(packageIDs, pkgs[i].ToPackageID)
+		}
+		logger.Info(fmt.Sprintf("upgrading %d packages on system %d", len(packageIDs), systemID))
+		actionID, err := s.proxy.SystemSchedulePackageInstall(auth, systemID, packageIDs)
+		if err != nil {
+			return err
+		}
+		_, err = s.proxy.CheckProgress(auth, actionID, timeout, "SystemSchedulePackageInstall", systemID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrNotFound is returned when the api reports that the requested object doesn't exist
+var ErrNotFound = errors.New("not found")
+
+// notFoundFault matches the messages of the api faults for objects that don't exist
+var notFoundFault = regexp.MustCompile(`(?i)no such|not found|does not exist|invalid kickstart tree|unable to locate`)
+
+// faultError - error for a failed api call, wrapping ErrNotFound when the fault reports a missing object. Other faults
+// are returned as the given return code only.
+//
+// param: fault
+// param: code
+// return: error
+func faultError(fault error, code string) error {
+	if notFoundFault.MatchString(fault.Error()) {
+		return fmt.Errorf("%v: %v: %w", code, fault, ErrNotFound)
+	}
+	return errors.New(code)
+}
+
 // HandleSuseManagerResponse - handle API response
 //
 // param: body
@@ -203,6 +338,25 @@ func HandleSuseManagerResponse(body []byte) (interface{}, error) {
 	return false, errors.New("Unexpected error")
 }
 
+// Sleep - wait for the given duration. Returns the error of the context when it is cancelled before.
+//
+// param: ctx
+// param: d
+// return: error
+func Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // AuthParams - authentication key
 type AuthParams struct {
 	SessionKey string
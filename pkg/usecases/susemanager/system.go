@@ -7,6 +7,7 @@ import (
 	"fmt"
 	log "mlmtool/pkg/util/logger"
 	"net/http"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,6 +16,12 @@ import (
 	returnCodes "mlmtool/pkg/util/returnCodes"
 )
 
+// ErrActionTimeout is returned when a scheduled action is not finished within the given timeout
+var ErrActionTimeout = errors.New("ran into timeout")
+
+// ErrActionFailed is returned when a scheduled action failed on the system
+var ErrActionFailed = errors.New("action failed")
+
 // SystemGetID - get systemID from the given server
 //
 // param: auth
@@ -30,7 +37,7 @@ func (p *Proxy) SystemGetID(auth AuthParams, systemName string) ([]sumamodels.Sy
 	path := "system/getId"
 	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
 	if err != nil {
-		log.Error(fmt.Sprintf("unable to get system info: %s", response.Body))
+		log.Error(fmt.Sprintf("unable to get system info: %v", err))
 		return nil, errors.New(returnCodes.ErrSystemNotFound)
 	}
 	if response.StatusCode == 200 {
@@ -70,7 +77,7 @@ func (p *Proxy) SchedulePackageRefresh(auth AuthParams, systemID int) error {
 	path := "system/schedulePackageRefresh"
 	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
 	if err != nil {
-		log.Error(fmt.Sprintf("error while scheduling package refresh err: %s", response.Body))
+		log.Error(fmt.Sprintf("error while scheduling package refresh err: %v", err))
 		return errors.New(returnCodes.ErrProcessingData)
 	}
 	return p.CheckResponseProgress(auth, response, 12000, systemID, "SchedulePackageRefresh")
@@ -156,99 +163,57 @@ func (p *Proxy) SystemGetScriptResult(auth AuthParams, actionID int, resultCompl
 // param: systemID
 // param: timeout
 func (p *Proxy) SystemScheduleReboot(auth AuthParams, systemID int, timeout int) error {
-	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "earliestOccurrence": time.Now()})
-	if err != nil {
-		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
-		return fmt.Errorf(returnCodes.ErrFailedMarshalling)
-	}
-	path := "system/scheduleReboot"
-	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	actionID, err := p.SystemScheduleRebootAction(auth, systemID)
 	if err != nil {
-		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
-		return fmt.Errorf(returnCodes.ErrProcessingData)
+		return err
 	}
-	return p.CheckResponseProgress(auth, response, timeout, systemID, "SystemScheduleReboot")
+	_, err = p.CheckProgress(auth, actionID, timeout, "SystemScheduleReboot", systemID)
+	return err
 }
 
-// ListInprogressSystem - list systems with running actions started from SUSE Manager
+// SystemScheduleRebootAction - schedule a reboot of the given system, without waiting for it
 //
 // param: auth
-// param: actionID
-// return:
-func (p *Proxy) ListInprogressSystem(auth AuthParams, actionID int) ([]interface{}, error) {
-	body, err := json.Marshal(map[string]interface{}{"actionId": actionID})
+// param: systemID
+// return: int, error
+func (p *Proxy) SystemScheduleRebootAction(auth AuthParams, systemID int) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "earliestOccurrence": time.Now()})
 	if err != nil {
 		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
-		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
 	}
-	path := "schedule/listInProgressSystems"
-	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	path := "system/scheduleReboot"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
 	if err != nil {
 		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
-		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
 	}
-	var resultSuc []interface{}
+	var actionID int
 	if response.StatusCode == 200 {
 		resp, err := HandleSuseManagerResponse(response.Body)
 		if err != nil {
 			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
-			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+			return 0, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
 		}
 		byteArray, err := json.Marshal(resp)
 		if err != nil {
 			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
-			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+			return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
 		}
-		err = json.Unmarshal(byteArray, &resultSuc)
+		err = json.Unmarshal(byteArray, &actionID)
 		if err != nil {
 			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
-			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
-		}
-	}
-	log.Debug("Response from api", zap.Any("api", "schedule/listInProgressSystems"), zap.Any("result", resultSuc))
-	return resultSuc, nil
-}
-
-// ListCompleteSystem - list system with completed actions started by SUSE Manager
-//
-// param: auth
-// param: actionID
-// return:
-func (p *Proxy) ListCompleteSystem(auth AuthParams, actionID int) ([]interface{}, error) {
-	body, err := json.Marshal(map[string]interface{}{"actionId": actionID})
-	if err != nil {
-		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
-		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
-	}
-	path := "schedule/listCompletedSystems"
-	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
-	if err != nil {
-		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
-		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
-	}
-	var resultSuc []interface{}
-	if response.StatusCode == 200 {
-		resp, err := HandleSuseManagerResponse(response.Body)
-		if err != nil {
-			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
-			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
-		}
-		byteArray, err := json.Marshal(resp)
-		if err != nil {
-			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
-			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
-		}
-		err = json.Unmarshal(byteArray, &resultSuc)
-		if err != nil {
-			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
-			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+			return 0, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
 		}
+	} else {
+		log.Error(fmt.Sprintf("running SystemScheduleReboot Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
 	}
-	log.Debug("response from api", zap.Any("api", "schedule/listCompletedSystems"), zap.Any("result", resultSuc))
-	return resultSuc, nil
+	return actionID, nil
 }
 
-// CheckProgress - check the progress of the given action on the given system
+// CheckProgress - check the progress of the given action on the given system. When the action failed, the
+// failure message of the system is returned in an error wrapping ErrActionFailed
 //
 // param: auth
 // param: actionID
@@ -259,22 +224,34 @@ func (p *Proxy) ListCompleteSystem(auth AuthParams, actionID int) ([]interface{}
 func (p *Proxy) CheckProgress(auth AuthParams, actionID int, timeout int, action string, systemID int) (int, error) {
 	endTime := time.Now().Add(time.Second * time.Duration(timeout))
 	waitTime := 15
-	inProgress, err := p.ListInprogressSystem(auth, actionID)
+	inProgress, err := p.ScheduleListInProgressSystems(auth, actionID)
 	if err != nil {
-		return 0, nil
+		return 0, p.interrupted(err, actionID, action, systemID)
 	}
 	for len(inProgress) > 0 {
 		if time.Now().After(endTime) {
 			log.Error("action ran in timeout", zap.Any("action", action), zap.Any("systemID", systemID))
-			return 0, fmt.Errorf("action: %s ran into timeout", action)
+			return 0, fmt.Errorf("action: %s %w", action, ErrActionTimeout)
 		}
-		time.Sleep(time.Second * time.Duration(waitTime))
-		inProgress, err = p.ListInprogressSystem(auth, actionID)
+		if err := Sleep(p.Context(), time.Second*time.Duration(waitTime)); err != nil {
+			return 0, p.interrupted(err, actionID, action, systemID)
+		}
+		inProgress, err = p.ScheduleListInProgressSystems(auth, actionID)
 		if err != nil {
-			return 0, nil
+			return 0, p.interrupted(err, actionID, action, systemID)
 		}
 	}
-	completedSystems, err := p.ListCompleteSystem(auth, actionID)
+	failedSystems, err := p.ScheduleListFailedSystems(auth, actionID)
+	if err != nil {
+		return 0, err
+	}
+	for _, failed := range failedSystems {
+		if systemID == 0 || failed.ServerID == systemID {
+			log.Error("action failed", zap.Any("action", action), zap.Any("system", failed.ServerName), zap.Any("message", failed.Message))
+			return 0, fmt.Errorf("action %s failed on %s: %s: %w", action, failed.ServerName, strings.TrimSpace(failed.Message), ErrActionFailed)
+		}
+	}
+	completedSystems, err := p.ScheduleListCompletedSystems(auth, actionID)
 	if err != nil {
 		return 0, err
 	}
@@ -284,6 +261,15 @@ func (p *Proxy) CheckProgress(auth AuthParams, actionID int, timeout int, action
 	return 0, fmt.Errorf("action %s is not completed", action)
 }
 
+// interrupted - error for a wait on an action cancelled by the context, the action itself keeps running on the
+// server. Returns err unchanged when the context is not cancelled.
+func (p *Proxy) interrupted(err error, actionID int, action string, systemID int) error {
+	if p.Context().Err() == nil {
+		return err
+	}
+	return fmt.Errorf("interrupted while waiting for action %v (%v) on system %v, it is still scheduled: %w", actionID, action, systemID, p.Context().Err())
+}
+
 // SystemListInstalledPackages - list installed packages on the given system
 //
 // param: auth
@@ -510,3 +496,473 @@ func (p *Proxy) SystemGetSubscribedBaseChannel(auth AuthParams, systemID int) (s
 	}
 	return result, nil
 }
+
+// SystemGetRelevantErrata - list the errata applicable to the given system
+//
+// param: auth
+// param: systemID
+// return: []sumamodels.Errata, error
+func (p *Proxy) SystemGetRelevantErrata(auth AuthParams, systemID int) ([]sumamodels.Errata, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/getRelevantErrata"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var errata []sumamodels.Errata
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &errata)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching relevant errata Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return errata, nil
+}
+
+// SystemListLatestUpgradablePackages - list the packages on the given system that have a newer version available
+//
+// param: auth
+// param: systemID
+// return: []sumamodels.UpgradablePackage, error
+func (p *Proxy) SystemListLatestUpgradablePackages(auth AuthParams, systemID int) ([]sumamodels.UpgradablePackage, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/listLatestUpgradablePackages"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var pkgs []sumamodels.UpgradablePackage
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &pkgs)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching upgradable packages Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return pkgs, nil
+}
+
+// SystemScheduleApplyErrata - schedule applying the given errata on the given system
+//
+// param: auth
+// param: systemID
+// param: errataIDs
+// return: []int with the scheduled action ids, error
+func (p *Proxy) SystemScheduleApplyErrata(auth AuthParams, systemID int, errataIDs []int) ([]int, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "errataIds": errataIDs, "earliestOccurrence": time.Now()})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/scheduleApplyErrata"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var actionIDs []int
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &actionIDs)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("running SystemScheduleApplyErrata Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return actionIDs, nil
+}
+
+// SystemSchedulePackageInstall - schedule installing the given packages on the given system
+//
+// param: auth
+// param: systemID
+// param: packageIDs
+// return: int with the scheduled action id, error
+func (p *Proxy) SystemSchedulePackageInstall(auth AuthParams, systemID int, packageIDs []int) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "packageIds": packageIDs, "earliestOccurrence": time.Now()})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/schedulePackageInstall"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var actionID int
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return 0, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &actionID)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("running SystemSchedulePackageInstall Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return actionID, nil
+}
+
+// SystemListSuggestedReboot - list the systems that require a reboot
+//
+// param: auth
+// return: []sumamodels.System, error
+func (p *Proxy) SystemListSuggestedReboot(auth AuthParams) ([]sumamodels.System, error) {
+	path := "system/listSuggestedReboot"
+	response, err := p.suse.SuseManagerCall(nil, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting list of systems requiring reboot. Error: %s", err)
+	}
+	var systems []sumamodels.System
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &systems)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("calling suggested reboot api Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return systems, nil
+}
+
+// SystemListMigrationTargets - list the possible product migration targets for the given system
+//
+// param: auth
+// param: systemID
+// return: []sumamodels.MigrationTarget, error
+func (p *Proxy) SystemListMigrationTargets(auth AuthParams, systemID int) ([]sumamodels.MigrationTarget, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "excludeTargetWhereMissingSuccessors": true})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/listMigrationTargets"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var targets []sumamodels.MigrationTarget
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &targets)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching migration targets Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return targets, nil
+}
+
+// SystemScheduleProductMigration - schedule a product migration (service pack migration) of the given system
+//
+// param: auth
+// param: systemID
+// param: targetIdent
+// param: baseChannel
+// param: childChannels
+// param: dryRun
+// param: timeout
+func (p *Proxy) SystemScheduleProductMigration(auth AuthParams, systemID int, targetIdent string, baseChannel string, childChannels []string, dryRun bool, timeout int) error {
+	log.Debug("Schedule product migration api called")
+	body, err := json.Marshal(map[string]interface{}{
+		"sid":                   systemID,
+		"targetIdent":           targetIdent,
+		"baseChannelLabel":      baseChannel,
+		"optionalChildChannels": childChannels,
+		"dryRun":                dryRun,
+		"allowVendorChange":     false,
+		"earliestOccurrence":    time.Now()})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/scheduleProductMigration"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return p.CheckResponseProgress(auth, response, timeout, systemID, "SystemScheduleProductMigration")
+}
+
+// SystemListSystems - list all systems registered to SUSE Manager
+//
+// param: auth
+// return: []sumamodels.System, error
+func (p *Proxy) SystemListSystems(auth AuthParams) ([]sumamodels.System, error) {
+	path := "system/listSystems"
+	response, err := p.suse.SuseManagerCall(nil, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting list of systems. Error: %s", err)
+	}
+	var systems []sumamodels.System
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &systems)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("calling list systems api Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return systems, nil
+}
+
+// SystemScheduleHighstate - schedule a SALT highstate on the given system, optionally in test mode
+//
+// param: auth
+// param: systemID
+// param: test
+// return: actionID, error
+func (p *Proxy) SystemScheduleHighstate(auth AuthParams, systemID int, test bool) (int, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "earliestOccurrence": time.Now(), "test": test})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/scheduleApplyHighstate"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var actionID int
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return 0, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &actionID)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return 0, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("running SystemScheduleHighstate Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return 0, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return actionID, nil
+}
+
+// SystemListSystemEvents - list the events of the given system, scheduled after the given date
+//
+// param: auth
+// param: systemID
+// param: earliestDate
+// return: []sumamodels.SystemEvent, error
+func (p *Proxy) SystemListSystemEvents(auth AuthParams, systemID int, earliestDate time.Time) ([]sumamodels.SystemEvent, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID, "earliestDate": earliestDate})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/listSystemEvents"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var events []sumamodels.SystemEvent
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &events)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("fetching system events Failed. Http StatusCode: %v Http Response body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return events, nil
+}
+
+// SystemObtainReactivationKey - generate a reactivation key for the given system
+//
+// param: auth
+// param: systemID
+// return: reactivation key, error
+func (p *Proxy) SystemObtainReactivationKey(auth AuthParams, systemID int) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return "", fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/obtainReactivationKey"
+	response, err := p.suse.SuseManagerCall(body, http.MethodPost, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return "", fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var key string
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return "", fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return "", fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &key)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return "", fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("obtaining reactivation key Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return "", fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return key, nil
+}
+
+// SystemGetConnectionPath - list the proxies the given system connects through, the proxy nearest to the system
+// first. Empty when the system connects to the server directly.
+//
+// param: auth
+// param: systemID
+// return: []sumamodels.SystemConnectionPath, error
+func (p *Proxy) SystemGetConnectionPath(auth AuthParams, systemID int) ([]sumamodels.SystemConnectionPath, error) {
+	body, err := json.Marshal(map[string]interface{}{"sid": systemID})
+	if err != nil {
+		log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+		return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+	}
+	path := "system/getConnectionPath"
+	response, err := p.suse.SuseManagerCall(body, http.MethodGet, auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("Error message recieved from suse-manger", zap.Any("error", err))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	var result []sumamodels.SystemConnectionPath
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrHandlingSuseManagerResponse, err))
+			return nil, fmt.Errorf(returnCodes.ErrHandlingSuseManagerResponse)
+		}
+		byteArray, err := json.Marshal(resp)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedMarshalling)
+		}
+		err = json.Unmarshal(byteArray, &result)
+		if err != nil {
+			log.Error(fmt.Sprintf("%v error %v", returnCodes.ErrFailedUnMarshalling, err))
+			return nil, fmt.Errorf(returnCodes.ErrFailedUnMarshalling)
+		}
+	} else {
+		log.Error(fmt.Sprintf("getting connection path Failed. Http StatusCode: %v Http Body: %v", response.StatusCode, string(response.Body)))
+		return nil, fmt.Errorf(returnCodes.ErrProcessingData)
+	}
+	return result, nil
+}
@@ -132,3 +132,82 @@ func (p *Proxy) SystemGroupListActiveSystemsInGroup(auth AuthParams, groupName s
 	log.Debug("Response from api", zap.Any("api", "SystemGroupListSystemsMinimal"), zap.Any("response", resultSuc))
 	return resultSuc, nil
 }
+
+// SystemGroupAddOrRemoveSystems - add the given systems to or remove them from the given system group
+//
+// param: auth
+// param: groupName
+// param: systemIDs
+// param: add
+// return:
+func (p *Proxy) SystemGroupAddOrRemoveSystems(auth AuthParams, groupName string, systemIDs []int, add bool) error {
+	body, _ := json.Marshal(map[string]interface{}{"systemGroupName": groupName, "serverIds": systemIDs, "add": add})
+	path := "systemgroup/addOrRemoveSystems"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("error while changing systems of system group", zap.Any("error", err))
+		return fmt.Errorf("error while changing systems of system group err: %s", err)
+	}
+	if response.StatusCode == 200 {
+		_, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			return fmt.Errorf("error while handling suse manager response err: %s", err)
+		}
+	} else {
+		return fmt.Errorf("calling system group addOrRemoveSystems Failed. Http StatusCode: %s", fmt.Sprint(response.StatusCode))
+	}
+	return nil
+}
+
+// SystemGroupDelete - delete the given system group
+//
+// param: auth
+// param: groupName
+// return:
+func (p *Proxy) SystemGroupDelete(auth AuthParams, groupName string) error {
+	body, _ := json.Marshal(map[string]interface{}{"systemGroupName": groupName})
+	path := "systemgroup/delete"
+	response, err := p.suse.SuseManagerCall(body, "POST", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("error while deleting system group", zap.Any("error", err))
+		return fmt.Errorf("error while deleting system group err: %s", err)
+	}
+	if response.StatusCode == 200 {
+		_, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			return fmt.Errorf("error while handling suse manager response err: %s", err)
+		}
+	} else {
+		return fmt.Errorf("calling system group delete Failed. Http StatusCode: %s", fmt.Sprint(response.StatusCode))
+	}
+	return nil
+}
+
+// SystemGroupListAllGroups - list all system groups
+//
+// param: auth
+// return:
+func (p *Proxy) SystemGroupListAllGroups(auth AuthParams) ([]sumamodels.SystemGroupGetDetails, error) {
+	path := "systemgroup/listAllGroups"
+	response, err := p.suse.SuseManagerCall(nil, "GET", auth.Host, path, auth.SessionKey)
+	if err != nil {
+		log.Error("error while fetching system groups", zap.Any("error", err))
+		return nil, fmt.Errorf("error while fetching system groups err: %s", err)
+	}
+	var resultSuc []sumamodels.SystemGroupGetDetails
+	if response.StatusCode == 200 {
+		resp, err := HandleSuseManagerResponse(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error while handling suse manager response err: %s", err)
+		}
+		byteArray, _ := json.Marshal(resp)
+		err = json.Unmarshal(byteArray, &resultSuc)
+		if err != nil {
+			log.Error("unmarshling error", zap.Any("error", err))
+			return nil, fmt.Errorf("unable to process the received data. err: %s", err)
+		}
+	} else {
+		return nil, fmt.Errorf("calling system group listAllGroups Failed. Http StatusCode: %s", fmt.Sprint(response.StatusCode))
+	}
+	return resultSuc, nil
+}
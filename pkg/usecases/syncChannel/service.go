@@ -0,0 +1,5 @@
+package syncChannel
+
+type ISyncChannel interface {
+	SyncChannel() error
+}
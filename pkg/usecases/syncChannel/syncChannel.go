@@ -0,0 +1,211 @@
+package syncChannel
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	csp "mlmtool/pkg/models/syncChannel"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+	"time"
+)
+
+// channelResult - outcome of the sync of a single channel
+type channelResult struct {
+	label    string
+	status   string
+	lastSync time.Time
+	duration time.Duration
+	err      error
+}
+
+type SyncChannel struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                csp.InputData
+}
+
+func NewSyncChannel(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input csp.InputData) *SyncChannel {
+	return &SyncChannel{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SyncChannel triggers a repository sync for the given channel and, when requested, all its children.
+// With wait enabled it waits until the last sync reported by every channel differs from the one before the trigger.
+// A summary per channel is printed. Returns an error when one or more channels failed to sync.
+func (h *SyncChannel) SyncChannel() error {
+	log.Debug("SyncChannel started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	err = h.validateSyncChannel(authParm)
+	if err != nil {
+		return err
+	}
+	channels, err := h.getChannels(authParm)
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
+	results := h.doSyncChannel(authParm, channels)
+	if h.input.Wait {
+		h.waitUntilSynced(authParm, results, startTime)
+	}
+	failed := h.printSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("sync failed for %v of %v channels", failed, len(results))
+	}
+	log.Info("SyncChannel finished")
+	return nil
+}
+
+func (h *SyncChannel) validateSyncChannel(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("syncChannel validateSyncChannel started")
+	if len(h.input.Channel) == 0 {
+		return fmt.Errorf("channel is mandatory")
+	}
+	channelPresent, err := h.sumanProxy.ChannelSoftwareIsExisting(authParm, h.input.Channel)
+	if err != nil {
+		return err
+	}
+	if !channelPresent {
+		return fmt.Errorf("given channel %v doesn't exist", h.input.Channel)
+	}
+	log.Debug("syncChannel validateSyncChannel finished")
+	return nil
+}
+
+// getChannels returns the given channel and, when requested, the labels of all its children.
+func (h *SyncChannel) getChannels(authParm _sumanUseCase.AuthParams) ([]string, error) {
+	channels := []string{h.input.Channel}
+	if !h.input.WithChildren {
+		return channels, nil
+	}
+	children, err := h.sumanProxy.ChannelSoftwareListChildren(authParm, h.input.Channel)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		channels = append(channels, child.Label)
+	}
+	return channels, nil
+}
+
+// doSyncChannel triggers the repository sync for all given channels. When waiting, the last sync of each channel is
+// saved before the trigger, a channel whose details can't be read is not triggered.
+func (h *SyncChannel) doSyncChannel(authParm _sumanUseCase.AuthParams, channels []string) []*channelResult {
+	log.Debug("doSyncChannel started")
+	var results []*channelResult
+	for _, channel := range channels {
+		result := &channelResult{label: channel, status: "triggered"}
+		if h.input.Wait {
+			details, err := h.sumanProxy.ChannelSoftwareGetDetails(authParm, channel)
+			if err != nil {
+				log.Error(fmt.Sprintf("unable to get details of channel %v: %v", channel, err))
+				result.status = "failed"
+				result.err = err
+				results = append(results, result)
+				continue
+			}
+			result.lastSync = time.Time(details.YumrepoLastSync)
+		}
+		_, err := h.sumanProxy.ChannelSoftwareSyncRepo(authParm, channel)
+		if err != nil {
+			log.Error(fmt.Sprintf("unable to trigger sync for channel %v: %v", channel, err))
+			result.status = "failed"
+			result.err = err
+		} else {
+			log.Info(fmt.Sprintf("sync triggered for channel %v", channel))
+		}
+		results = append(results, result)
+	}
+	log.Debug("doSyncChannel finished")
+	return results
+}
+
+// waitUntilSynced polls the channel details until the last sync of every triggered channel differs from the one saved
+// before the trigger, or until the timeout has passed. Only the server's timestamps are compared, the durations are
+// measured locally from startTime.
+func (h *SyncChannel) waitUntilSynced(authParm _sumanUseCase.AuthParams, results []*channelResult, startTime time.Time) {
+	log.Debug("waitUntilSynced started")
+	timeout := h.input.Timeout
+	if timeout == 0 {
+		timeout = h.suseoperationtimeout
+	}
+	endTime := startTime.Add(time.Second * time.Duration(timeout))
+	for {
+		pending := 0
+		for _, result := range results {
+			if result.status != "triggered" {
+				continue
+			}
+			details, err := h.sumanProxy.ChannelSoftwareGetDetails(authParm, result.label)
+			if err != nil {
+				log.Warn(fmt.Sprintf("unable to get details of channel %v: %v", result.label, err))
+				pending++
+				continue
+			}
+			if !time.Time(details.YumrepoLastSync).Equal(result.lastSync) {
+				result.status = "synced"
+				result.duration = time.Since(startTime)
+				log.Info(fmt.Sprintf("channel %v synced", result.label))
+				continue
+			}
+			pending++
+		}
+		if pending == 0 {
+			break
+		}
+		if time.Now().After(endTime) {
+			for _, result := range results {
+				if result.status == "triggered" {
+					result.status = "timeout"
+					result.duration = time.Since(startTime)
+					result.err = fmt.Errorf("channel %v not synced within %v seconds", result.label, timeout)
+				}
+			}
+			break
+		}
+		log.Info(fmt.Sprintf("waiting for %v channel(s) to be synced", pending))
+		if err := _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*30); err != nil {
+			for _, result := range results {
+				if result.status == "triggered" {
+					result.status = "interrupted"
+					result.duration = time.Since(startTime)
+					result.err = fmt.Errorf("channel %v is still being synced: %w", result.label, err)
+				}
+			}
+			break
+		}
+	}
+	log.Debug("waitUntilSynced finished")
+}
+
+// printSummary prints the result per channel and returns the number of failed channels.
+func (h *SyncChannel) printSummary(results []*channelResult) int {
+	failed := 0
+	fmt.Printf("%-60s %-10s %s\n", "channel", "status", "duration")
+	for _, result := range results {
+		duration := "-"
+		if result.duration > 0 {
+			duration = result.duration.Round(time.Second).String()
+		}
+		fmt.Printf("%-60s %-10s %s\n", result.label, result.status, duration)
+		if result.err != nil {
+			failed++
+		}
+	}
+	return failed
+}
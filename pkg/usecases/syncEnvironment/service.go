@@ -0,0 +1,5 @@
+package syncEnvironment
+
+type ISyncEnvironment interface {
+	SyncEnvironment() error
+}
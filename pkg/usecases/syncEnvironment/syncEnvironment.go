@@ -0,0 +1,163 @@
+package syncEnvironment
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	sumamodels "mlmtool/pkg/models/susemanager"
+	csp "mlmtool/pkg/models/syncEnvironment"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+	"reflect"
+	"time"
+)
+
+type SyncEnvironment struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                csp.InputData
+}
+
+func NewSyncEnvironment(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input csp.InputData) *SyncEnvironment {
+	return &SyncEnvironment{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SyncEnvironment builds the first environment of the project and promotes every next environment in order,
+// waiting for each stage to be built before continuing. When until is given, the run stops after that environment.
+// Returns an error if any step in the process fails, including login, validation, build or promote.
+func (h *SyncEnvironment) SyncEnvironment() error {
+	log.Debug("SyncEnvironment started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	environments, err := h.validateSyncEnvironment(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doSyncEnvironment(authParm, environments)
+	if err != nil {
+		return err
+	}
+	log.Info("SyncEnvironment finished")
+	return nil
+}
+
+// validateSyncEnvironment checks the input and returns the environments to be processed, in order.
+func (h *SyncEnvironment) validateSyncEnvironment(authParm _sumanUseCase.AuthParams) ([]sumamodels.ContentManagementEnvironmentList, error) {
+	log.Debug("syncEnvironment validateSyncEnvironment started")
+	if len(h.input.Project) == 0 {
+		return nil, fmt.Errorf("project name is mandatory")
+	}
+	if h.input.Soak < 0 {
+		return nil, fmt.Errorf("soak time cannot be negative")
+	}
+	project, err := h.sumanProxy.ContentManagementLookupProject(authParm, h.input.Project)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.ValueOf(project).IsZero() {
+		return nil, fmt.Errorf("project %v does not exist", h.input.Project)
+	}
+	environments, err := h.suse.OrderedEnvironments(authParm, h.input.Project)
+	if err != nil {
+		return nil, err
+	}
+	if len(environments) == 0 {
+		return nil, fmt.Errorf("project %v has no environments", h.input.Project)
+	}
+	if len(h.input.Until) > 0 {
+		for i, env := range environments {
+			if env.Label == h.input.Until {
+				log.Debug("syncEnvironment validateSyncEnvironment finished")
+				return environments[:i+1], nil
+			}
+		}
+		return nil, fmt.Errorf("project %v environment %v does not exist", h.input.Project, h.input.Until)
+	}
+	log.Debug("syncEnvironment validateSyncEnvironment finished")
+	return environments, nil
+}
+
+// doSyncEnvironment builds the first environment and promotes the following ones, one after another.
+func (h *SyncEnvironment) doSyncEnvironment(authParm _sumanUseCase.AuthParams, environments []sumamodels.ContentManagementEnvironmentList) error {
+	log.Debug("doSyncEnvironment started")
+	for i, env := range environments {
+		if i == 0 {
+			log.Info(fmt.Sprintf("building environment %v of project %v", env.Label, h.input.Project))
+			_, err := h.sumanProxy.ContentManagementBuildProject(authParm, h.input.Project)
+			if err != nil {
+				return err
+			}
+			err = h.waitUntilBuilt(authParm, env.Label, env.Version+1)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if h.input.Soak > 0 {
+			log.Info(fmt.Sprintf("waiting %v seconds before promoting to environment %v", h.input.Soak, env.Label))
+			err := _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*time.Duration(h.input.Soak))
+			if err != nil {
+				return fmt.Errorf("interrupted before promoting to environment %v of project %v: %w", env.Label, h.input.Project, err)
+			}
+		}
+		previous, err := h.sumanProxy.ContentManagementLookupEnvironment(authParm, h.input.Project, env.PreviousEnvironmentLabel)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("promoting environment %v to %v of project %v", previous.Label, env.Label, h.input.Project))
+		_, err = h.sumanProxy.ContentManagementPromoteProject(authParm, h.input.Project, previous.Label)
+		if err != nil {
+			return err
+		}
+		err = h.waitUntilBuilt(authParm, env.Label, previous.Version)
+		if err != nil {
+			return err
+		}
+	}
+	log.Debug("doSyncEnvironment finished")
+	return nil
+}
+
+// waitUntilBuilt waits until the given environment is built with at least the given version. Returns an error wrapping
+// ErrActionTimeout when the build doesn't finish within suman.timeout seconds.
+func (h *SyncEnvironment) waitUntilBuilt(authParm _sumanUseCase.AuthParams, envLabel string, version int) error {
+	log.Debug("waitUntilBuilt started")
+	endTime := time.Now().Add(time.Second * time.Duration(h.suseoperationtimeout))
+	for {
+		environment, err := h.sumanProxy.ContentManagementLookupEnvironment(authParm, h.input.Project, envLabel)
+		if err != nil {
+			return err
+		}
+		if environment.Status == "failed" {
+			return fmt.Errorf("building environment %v of project %v failed", envLabel, h.input.Project)
+		}
+		if environment.Status == "built" && environment.Version >= version {
+			log.Info(fmt.Sprintf("environment %v is built with version %v", envLabel, environment.Version))
+			break
+		}
+		if time.Now().After(endTime) {
+			return fmt.Errorf("building environment %v of project %v: %w", envLabel, h.input.Project, _sumanUseCase.ErrActionTimeout)
+		}
+		log.Info(fmt.Sprintf("waiting for environment %v to be built", envLabel))
+		err = _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*30)
+		if err != nil {
+			return fmt.Errorf("interrupted while environment %v of project %v is being built, the build continues on the server: %w", envLabel, h.input.Project, err)
+		}
+	}
+	log.Debug("waitUntilBuilt finished")
+	return nil
+}
@@ -0,0 +1,5 @@
+package syncMoveServer
+
+type ISyncMoveServer interface {
+	SyncMoveServer() error
+}
@@ -0,0 +1,162 @@
+package syncMoveServer
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	sms "mlmtool/pkg/models/syncMoveServer"
+	"os"
+	"path/filepath"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/checksumaserver"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+type SyncMoveServer struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                sms.InputData
+}
+
+func NewSyncMoveServer(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input sms.InputData) *SyncMoveServer {
+	return &SyncMoveServer{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SyncMoveServer configures the Inter-Server Sync of this server. On the primary the given secondary is registered,
+// or deregistered with delete. On a secondary the given primary is registered with its CA certificate and made the
+// default, or deregistered with delete.
+func (h *SyncMoveServer) SyncMoveServer() error {
+	log.Debug("SyncMoveServer started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	err = h.validateSyncMoveServer()
+	if err != nil {
+		return err
+	}
+	if checksumaserver.Primary() {
+		err = h.doPrimary(authParm)
+	} else {
+		err = h.doSecondary(authParm)
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("SyncMoveServer finished")
+	return nil
+}
+
+// validateSyncMoveServer checks the role of this server and the parameters needed for that role.
+func (h *SyncMoveServer) validateSyncMoveServer() error {
+	log.Debug("syncMoveServer validateSyncMoveServer started")
+	primary := checksumaserver.Primary()
+	secondary := checksumaserver.Secondary()
+	switch {
+	case primary && secondary:
+		return fmt.Errorf("this server is marked both as primary and as secondary")
+	case primary:
+		if len(h.input.Secondary) == 0 {
+			return fmt.Errorf("secondary is mandatory on the primary")
+		}
+	case secondary:
+		if len(h.input.Primary) == 0 {
+			return fmt.Errorf("primary is mandatory on a secondary")
+		}
+		if !h.input.Delete {
+			if len(h.input.CaCert) == 0 {
+				return fmt.Errorf("ca certificate of the primary is mandatory on a secondary")
+			}
+			if _, err := os.Stat(filepath.Clean(h.input.CaCert)); err != nil {
+				return fmt.Errorf("ca certificate %v not found: %v", h.input.CaCert, err)
+			}
+		}
+	default:
+		return fmt.Errorf("this server is marked neither as primary nor as secondary")
+	}
+	log.Debug("syncMoveServer validateSyncMoveServer finished")
+	return nil
+}
+
+// doPrimary registers or deregisters the secondary on the primary.
+func (h *SyncMoveServer) doPrimary(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("syncMoveServer doPrimary started")
+	slave, err := h.sumanProxy.SyncSlaveGetSlaveByName(authParm, h.input.Secondary)
+	registered := err == nil && slave.ID > 0
+	if h.input.Delete {
+		if !registered {
+			fmt.Printf("secondary %v is not registered\n", h.input.Secondary)
+			return nil
+		}
+		log.Info(fmt.Sprintf("deregistering secondary %v", h.input.Secondary))
+		_, err = h.sumanProxy.SyncSlaveDelete(authParm, slave.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("secondary %v deregistered\n", h.input.Secondary)
+		return nil
+	}
+	if registered {
+		fmt.Printf("secondary %v is already registered with id %v\n", h.input.Secondary, slave.ID)
+		return nil
+	}
+	log.Info(fmt.Sprintf("registering secondary %v", h.input.Secondary))
+	slave, err = h.sumanProxy.SyncSlaveCreate(authParm, h.input.Secondary, true, true)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("secondary %v registered with id %v\n", h.input.Secondary, slave.ID)
+	log.Debug("syncMoveServer doPrimary finished")
+	return nil
+}
+
+// doSecondary registers the primary with its CA certificate and makes it the default, or deregisters it.
+func (h *SyncMoveServer) doSecondary(authParm _sumanUseCase.AuthParams) error {
+	log.Debug("syncMoveServer doSecondary started")
+	master, err := h.sumanProxy.SyncMasterGetMasterByLabel(authParm, h.input.Primary)
+	registered := err == nil && master.ID > 0
+	if h.input.Delete {
+		if !registered {
+			fmt.Printf("primary %v is not registered\n", h.input.Primary)
+			return nil
+		}
+		log.Info(fmt.Sprintf("deregistering primary %v", h.input.Primary))
+		_, err = h.sumanProxy.SyncMasterDelete(authParm, master.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("primary %v deregistered\n", h.input.Primary)
+		return nil
+	}
+	if !registered {
+		log.Info(fmt.Sprintf("registering primary %v", h.input.Primary))
+		master, err = h.sumanProxy.SyncMasterCreate(authParm, h.input.Primary)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = h.sumanProxy.SyncMasterSetCaCert(authParm, master.ID, h.input.CaCert)
+	if err != nil {
+		return err
+	}
+	_, err = h.sumanProxy.SyncMasterMakeDefault(authParm, master.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("primary %v registered with id %v and made the default\n", h.input.Primary, master.ID)
+	log.Debug("syncMoveServer doSecondary finished")
+	return nil
+}
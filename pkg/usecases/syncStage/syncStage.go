@@ -127,7 +127,10 @@ func (h *SyncStage) waitUntilFinished(authParm _sumanUseCase.AuthParams) error {
 			break
 		}
 		log.Info(fmt.Sprintf("waiting for environment %v to be built", environment.Label))
-		time.Sleep(time.Second * 30)
+		err = _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*30)
+		if err != nil {
+			return fmt.Errorf("interrupted while environment %v of project %v is being built, the build continues on the server: %w", environment.Label, h.input.Project, err)
+		}
 	}
 	log.Debug("waitUntilFinished finished")
 	return nil
@@ -0,0 +1,103 @@
+package syncStage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/models/inputfile"
+	csp "mlmtool/pkg/models/syncStage"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestSyncStage(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          csp.InputData
+		status         map[string]string
+		failure        string
+		expectError    bool
+		expectedCall   string
+		expectedStatus map[string]string
+	}{
+		{
+			name:           "build first environment",
+			input:          csp.InputData{Project: "sles15", Environment: "dev", Wait: true},
+			expectedCall:   "contentmanagement/buildProject",
+			expectedStatus: map[string]string{"dev": "built", "test": "unknown", "prod": "unknown"},
+		},
+		{
+			name:           "promote to next environment",
+			input:          csp.InputData{Project: "sles15", Environment: "test", Wait: true},
+			status:         map[string]string{"dev": "built"},
+			expectedCall:   "contentmanagement/promoteProject",
+			expectedStatus: map[string]string{"dev": "built", "test": "built", "prod": "unknown"},
+		},
+		{
+			name:        "previous environment never built",
+			input:       csp.InputData{Project: "sles15", Environment: "prod"},
+			status:      map[string]string{"dev": "built"},
+			expectError: true,
+		},
+		{
+			name:        "previous environment still building",
+			input:       csp.InputData{Project: "sles15", Environment: "test"},
+			status:      map[string]string{"dev": "building"},
+			expectError: true,
+		},
+		{
+			name:        "unknown project",
+			input:       csp.InputData{Project: "sles12", Environment: "dev"},
+			expectError: true,
+		},
+		{
+			name:        "unknown environment",
+			input:       csp.InputData{Project: "sles15", Environment: "qa"},
+			expectError: true,
+		},
+		{
+			name:        "build fails",
+			input:       csp.InputData{Project: "sles15", Environment: "dev"},
+			failure:     "contentmanagement/buildProject",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			fake.AddChannel("sles15-pool", "")
+			fake.AddProject("sles15", "dev", "test", "prod")
+			for env, status := range tt.status {
+				fake.SetEnvironmentStatus("sles15", env, status)
+			}
+			if len(tt.failure) > 0 {
+				fake.Fail(tt.failure, "injected failure", 0)
+			}
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			proxy := fake.Proxy()
+			h := NewSyncStage(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 60, genConfig, tt.input)
+
+			err := h.SyncStage()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, fake.Calls(tt.expectedCall))
+			status := make(map[string]string)
+			for _, env := range fake.Environments("sles15") {
+				status[env.Label] = env.Status
+			}
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package systemHighstate
+
+type ISystemHighstate interface {
+	SystemHighstate() error
+}
@@ -0,0 +1,245 @@
+package systemHighstate
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	shs "mlmtool/pkg/models/systemHighstate"
+	"sort"
+	"strings"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+
+	"gopkg.in/yaml.v3"
+)
+
+// systemResult - outcome of the highstate of a single system
+type systemResult struct {
+	name      string
+	systemID  int
+	actionID  int
+	status    string
+	changed   []string
+	succeeded []string
+	failed    []string
+	err       error
+}
+
+type SystemHighstate struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                shs.InputData
+}
+
+func NewSystemHighstate(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input shs.InputData) *SystemHighstate {
+	return &SystemHighstate{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SystemHighstate applies the highstate, or with test enabled a dry run of it, on the given system or on all
+// active systems in the given group. After completion the result of each state is fetched and printed per system.
+// Returns an error when the highstate failed on one or more systems.
+func (h *SystemHighstate) SystemHighstate() error {
+	log.Debug("SystemHighstate started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systems, err := h.validateSystemHighstate(authParm)
+	if err != nil {
+		return err
+	}
+	results := h.doSystemHighstate(authParm, systems)
+	failed := h.printSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("highstate failed on %v of %v systems", failed, len(results))
+	}
+	log.Info("SystemHighstate finished")
+	return nil
+}
+
+// validateSystemHighstate checks either a server or a group is given and returns the systems to apply the highstate on.
+func (h *SystemHighstate) validateSystemHighstate(authParm _sumanUseCase.AuthParams) ([]sumamodels.SystemGroupListSystemsMinimal, error) {
+	log.Debug("systemHighstate validateSystemHighstate started")
+	if len(h.input.Server) == 0 && len(h.input.Group) == 0 {
+		return nil, fmt.Errorf("server or group is mandatory")
+	}
+	if len(h.input.Server) > 0 && len(h.input.Group) > 0 {
+		return nil, fmt.Errorf("server and group cannot be given both")
+	}
+	if len(h.input.Server) > 0 {
+		systems, err := h.sumanProxy.SystemGetID(authParm, h.input.Server)
+		if err != nil {
+			return nil, err
+		}
+		if len(systems) == 0 {
+			return nil, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, h.input.Server)
+		}
+		if len(systems) > 1 {
+			return nil, fmt.Errorf("more than one system found with name %v", h.input.Server)
+		}
+		return []sumamodels.SystemGroupListSystemsMinimal{{ID: systems[0].ID, Name: systems[0].Name}}, nil
+	}
+	active, err := h.suse.ActiveGroupSystems(authParm, h.input.Group)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) == 0 {
+		return nil, fmt.Errorf("no active systems in group %v", h.input.Group)
+	}
+	log.Debug("systemHighstate validateSystemHighstate finished")
+	return active, nil
+}
+
+// doSystemHighstate schedules the highstate on all systems at once, then waits for each action and collects its result.
+func (h *SystemHighstate) doSystemHighstate(authParm _sumanUseCase.AuthParams, systems []sumamodels.SystemGroupListSystemsMinimal) []*systemResult {
+	log.Debug("doSystemHighstate started")
+	startTime := time.Now().Add(-time.Minute)
+	var results []*systemResult
+	for _, system := range systems {
+		result := &systemResult{name: system.Name, systemID: system.ID, status: "scheduled"}
+		results = append(results, result)
+		actionID, err := h.sumanProxy.SystemScheduleHighstate(authParm, system.ID, h.input.Test)
+		if err != nil {
+			result.status = "failed"
+			result.err = err
+			continue
+		}
+		result.actionID = actionID
+		log.Info(fmt.Sprintf("highstate scheduled on system %v, action %v", system.Name, actionID))
+	}
+	for _, result := range results {
+		if result.status != "scheduled" {
+			continue
+		}
+		_, err := h.sumanProxy.CheckProgress(authParm, result.actionID, h.suseoperationtimeout, "SystemScheduleHighstate", result.systemID)
+		if err != nil {
+			result.status = "failed"
+			result.err = err
+		} else {
+			result.status = "completed"
+		}
+		err = h.collectStates(authParm, result, startTime)
+		if err != nil && result.err == nil {
+			result.status = "failed"
+			result.err = err
+		}
+		if len(result.failed) > 0 && result.err == nil {
+			result.status = "failed"
+			result.err = fmt.Errorf("%v states failed", len(result.failed))
+		}
+	}
+	log.Debug("doSystemHighstate finished")
+	return results
+}
+
+// collectStates fetches the result of the highstate action from the system events and sorts the states into
+// changed, succeeded and failed. In test mode, states that would change are reported as changed.
+func (h *SystemHighstate) collectStates(authParm _sumanUseCase.AuthParams, result *systemResult, startTime time.Time) error {
+	events, err := h.sumanProxy.SystemListSystemEvents(authParm, result.systemID, startTime)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if event.ID != result.actionID {
+			continue
+		}
+		found := false
+		for _, info := range event.AdditionalInfo {
+			var states map[string]shs.StateResult
+			err = yaml.Unmarshal([]byte(info.Result), &states)
+			if err != nil || len(states) == 0 {
+				continue
+			}
+			found = true
+			h.sortStates(result, states)
+		}
+		if !found {
+			if len(event.ResultMsg) > 0 {
+				return fmt.Errorf("no state results available: %v", event.ResultMsg)
+			}
+			return fmt.Errorf("no state results available")
+		}
+		return nil
+	}
+	return fmt.Errorf("action %v not found in the events of system %v", result.actionID, result.name)
+}
+
+// sortStates sorts the states into changed, succeeded and failed, in the order they were run.
+func (h *SystemHighstate) sortStates(result *systemResult, states map[string]shs.StateResult) {
+	var keys []string
+	for key := range states {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return states[keys[i]].RunNum < states[keys[j]].RunNum })
+	for _, key := range keys {
+		state := states[key]
+		id := state.ID
+		if len(id) == 0 {
+			id = key
+		}
+		switch {
+		case state.Result != nil && !*state.Result:
+			result.failed = append(result.failed, fmt.Sprintf("%v: %v", id, formatComment(state.Comment)))
+		case state.Result == nil || len(state.Changes) > 0:
+			result.changed = append(result.changed, id)
+		default:
+			result.succeeded = append(result.succeeded, id)
+		}
+	}
+}
+
+// formatComment returns the comment of a state, which salt returns either as string or as list of strings, on one line.
+func formatComment(comment interface{}) string {
+	switch c := comment.(type) {
+	case string:
+		return strings.ReplaceAll(c, "\n", " ")
+	case []interface{}:
+		var lines []string
+		for _, line := range c {
+			lines = append(lines, fmt.Sprint(line))
+		}
+		return strings.Join(lines, " ")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(c)
+	}
+}
+
+// printSummary prints the states per system and returns the number of failed systems.
+func (h *SystemHighstate) printSummary(results []*systemResult) int {
+	failed := 0
+	changedLabel := "changed"
+	if h.input.Test {
+		changedLabel = "would change"
+	}
+	for _, result := range results {
+		fmt.Printf("%v: %v, %v %v, succeeded %v, failed %v\n", result.name, result.status, changedLabel, len(result.changed), len(result.succeeded), len(result.failed))
+		for _, state := range result.changed {
+			fmt.Printf("    %v: %v\n", changedLabel, state)
+		}
+		for _, state := range result.failed {
+			fmt.Printf("    failed: %v\n", state)
+		}
+		if result.err != nil {
+			fmt.Printf("    error: %v\n", result.err)
+			failed++
+		}
+	}
+	return failed
+}
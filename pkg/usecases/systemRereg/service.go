@@ -0,0 +1,5 @@
+package systemRereg
+
+type ISystemRereg interface {
+	SystemRereg() error
+}
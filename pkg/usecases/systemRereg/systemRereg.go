@@ -0,0 +1,303 @@
+package systemRereg
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	srr "mlmtool/pkg/models/systemRereg"
+	"regexp"
+	"strings"
+	"time"
+
+	sumamodels "mlmtool/pkg/models/susemanager"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// reregScript downloads the bootstrap script from the new server or proxy, sets the reactivation key and runs it
+// in the background, as the bootstrap restarts the salt minion that is running this script. The download is verified
+// against the CA of the server the client already trusts, the proxies' certificates are signed by the same CA.
+const reregScript = `#!/bin/bash
+TARGET=%[1]v
+BOOTSTRAP=%[2]v
+KEY=%[3]v
+for CA in /etc/pki/trust/anchors/RHN-ORG-TRUSTED-SSL-CERT /etc/pki/ca-trust/source/anchors/RHN-ORG-TRUSTED-SSL-CERT /usr/local/share/ca-certificates/susemanager/RHN-ORG-TRUSTED-SSL-CERT.crt /usr/share/rhn/RHN-ORG-TRUSTED-SSL-CERT; do
+    [ -f "$CA" ] && break
+done
+[ -f "$CA" ] || { echo "trusted CA of the server not found" >&2; exit 1; }
+curl -Sfs --cacert "$CA" "https://${TARGET}/pub/bootstrap/${BOOTSTRAP}" -o /tmp/mlmtool_bootstrap.sh || exit 1
+sed -i "s/^REACTIVATION_KEY=.*/REACTIVATION_KEY=${KEY}/" /tmp/mlmtool_bootstrap.sh
+nohup bash /tmp/mlmtool_bootstrap.sh > /tmp/mlmtool_bootstrap.log 2>&1 &
+exit 0
+`
+
+// checkinInterval - time between two checks whether the re-registered systems checked in again
+var checkinInterval = 30 * time.Second
+
+// reactivationKey - format of the reactivation keys generated by the server, checked as the key is put into the script
+var reactivationKey = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// systemResult - outcome of the re-registration of a single system
+type systemResult struct {
+	name      string
+	systemID  int
+	scheduled time.Time
+	status    string
+	err       error
+}
+
+type SystemRereg struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                srr.InputData
+}
+
+func NewSystemRereg(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input srr.InputData) *SystemRereg {
+	return &SystemRereg{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SystemRereg re-registers the given system, or all clients of the given proxy, against the target server or proxy.
+// For every system a reactivation key is generated and a script re-bootstrapping the system is run on it, after which
+// the system has to check in again under the same profile. Clients of a proxy are handled in batches.
+// Returns an error when one or more systems failed to re-register.
+func (h *SystemRereg) SystemRereg() error {
+	log.Debug("SystemRereg started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systems, err := h.validateSystemRereg(authParm)
+	if err != nil {
+		return err
+	}
+	results := h.doSystemRereg(authParm, systems)
+	failed := h.printSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("re-registration failed for %v of %v systems", failed, len(results))
+	}
+	log.Info("SystemRereg finished")
+	return nil
+}
+
+// validateSystemRereg checks the parameters and returns the systems to be re-registered.
+func (h *SystemRereg) validateSystemRereg(authParm _sumanUseCase.AuthParams) ([]sumamodels.System, error) {
+	log.Debug("systemRereg validateSystemRereg started")
+	if len(h.input.Target) == 0 {
+		return nil, fmt.Errorf("target is mandatory")
+	}
+	if len(h.input.Server) == 0 && len(h.input.Proxy) == 0 {
+		return nil, fmt.Errorf("server or proxy is mandatory")
+	}
+	if len(h.input.Server) > 0 && len(h.input.Proxy) > 0 {
+		return nil, fmt.Errorf("server and proxy cannot be given both")
+	}
+	if h.input.BatchSize < 1 {
+		return nil, fmt.Errorf("batch size should be at least 1")
+	}
+	if len(h.input.Server) > 0 {
+		system, err := h.getSystem(authParm, h.input.Server)
+		if err != nil {
+			return nil, err
+		}
+		return []sumamodels.System{system}, nil
+	}
+	proxy, err := h.getSystem(authParm, h.input.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	clientIDs, err := h.sumanProxy.ProxyListProxyClients(authParm, proxy.ID)
+	if err != nil {
+		return nil, err
+	}
+	systems, err := h.sumanProxy.SystemListSystems(authParm)
+	if err != nil {
+		return nil, err
+	}
+	var clients []sumamodels.System
+	for _, system := range systems {
+		for _, id := range clientIDs {
+			if system.ID == id && system.ID != proxy.ID {
+				clients = append(clients, system)
+				break
+			}
+		}
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no clients found behind proxy %v", h.input.Proxy)
+	}
+	log.Debug("systemRereg validateSystemRereg finished")
+	return clients, nil
+}
+
+// getSystem returns the system with the given name, which should be known exactly once.
+func (h *SystemRereg) getSystem(authParm _sumanUseCase.AuthParams, name string) (sumamodels.System, error) {
+	systems, err := h.sumanProxy.SystemGetID(authParm, name)
+	if err != nil {
+		return sumamodels.System{}, err
+	}
+	if len(systems) == 0 {
+		return sumamodels.System{}, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, name)
+	}
+	if len(systems) > 1 {
+		return sumamodels.System{}, fmt.Errorf("more than one system found with name %v", name)
+	}
+	return systems[0], nil
+}
+
+// doSystemRereg re-registers the systems in batches of the given size. Each batch is waited for before the next starts.
+func (h *SystemRereg) doSystemRereg(authParm _sumanUseCase.AuthParams, systems []sumamodels.System) []*systemResult {
+	log.Debug("doSystemRereg started")
+	var results []*systemResult
+	for start := 0; start < len(systems); start += h.input.BatchSize {
+		end := start + h.input.BatchSize
+		if end > len(systems) {
+			end = len(systems)
+		}
+		if start > 0 {
+			err := _sumanUseCase.Sleep(h.sumanProxy.Context(), time.Second*time.Duration(h.genConfig.Maintenance.WaitBetweenSystems))
+			if err != nil {
+				for _, system := range systems[start:] {
+					results = append(results, &systemResult{name: system.Name, systemID: system.ID, status: "not started", err: err})
+				}
+				break
+			}
+		}
+		log.Info(fmt.Sprintf("re-registering systems %v to %v of %v", start+1, end, len(systems)))
+		var batch []*systemResult
+		for _, system := range systems[start:end] {
+			result := &systemResult{name: system.Name, systemID: system.ID}
+			h.reregister(authParm, result)
+			batch = append(batch, result)
+		}
+		h.waitForCheckin(authParm, batch)
+		results = append(results, batch...)
+	}
+	log.Debug("doSystemRereg finished")
+	return results
+}
+
+// reregister generates a reactivation key for the system and runs the re-bootstrap script on it.
+func (h *SystemRereg) reregister(authParm _sumanUseCase.AuthParams, result *systemResult) {
+	key, err := h.sumanProxy.SystemObtainReactivationKey(authParm, result.systemID)
+	if err != nil {
+		result.status = "failed"
+		result.err = fmt.Errorf("unable to obtain reactivation key: %v", err)
+		return
+	}
+	if !reactivationKey.MatchString(key) {
+		result.status = "failed"
+		result.err = fmt.Errorf("unexpected reactivation key format")
+		return
+	}
+	log.Info(fmt.Sprintf("re-registering system %v to %v", result.name, h.input.Target))
+	script := fmt.Sprintf(reregScript, shellQuote(h.input.Target), shellQuote(h.input.Bootstrap), key)
+	err = h.sumanProxy.ScheduleScriptRun(authParm, result.systemID, h.suseoperationtimeout, script)
+	if err != nil {
+		result.status = "failed"
+		result.err = fmt.Errorf("unable to run re-bootstrap script: %v", err)
+		return
+	}
+	// the completion of the script action is a check in as well, only later check ins count
+	result.scheduled = time.Now()
+	result.status = "scheduled"
+}
+
+// waitForCheckin waits until every scheduled system has checked in again under the same system id after the
+// re-bootstrap script was run and connects through the target, or until the timeout has passed.
+func (h *SystemRereg) waitForCheckin(authParm _sumanUseCase.AuthParams, results []*systemResult) {
+	endTime := time.Now().Add(time.Second * time.Duration(h.suseoperationtimeout))
+	for {
+		pending := 0
+		systems, err := h.sumanProxy.SystemListSystems(authParm)
+		if err != nil {
+			log.Warn(fmt.Sprintf("unable to list systems: %v", err))
+		}
+		for _, result := range results {
+			if result.status != "scheduled" {
+				continue
+			}
+			for _, system := range systems {
+				if system.ID == result.systemID && time.Time(system.LastChekin).After(result.scheduled) && h.connectsThroughTarget(authParm, result) {
+					result.status = "registered"
+					log.Info(fmt.Sprintf("system %v checked in again", result.name))
+					break
+				}
+			}
+			if result.status == "scheduled" {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+		if time.Now().After(endTime) {
+			for _, result := range results {
+				if result.status == "scheduled" {
+					result.status = "timeout"
+					result.err = fmt.Errorf("system %v did not check in within %v seconds", result.name, h.suseoperationtimeout)
+				}
+			}
+			return
+		}
+		log.Info(fmt.Sprintf("waiting for %v system(s) to check in", pending))
+		if err := _sumanUseCase.Sleep(h.sumanProxy.Context(), checkinInterval); err != nil {
+			for _, result := range results {
+				if result.status == "scheduled" {
+					result.status = "interrupted"
+					result.err = fmt.Errorf("system %v has not checked in yet, the re-bootstrap may still be running: %w", result.name, err)
+				}
+			}
+			return
+		}
+	}
+}
+
+// connectsThroughTarget checks that the system connects through the target proxy, or directly when the target is the
+// server itself.
+func (h *SystemRereg) connectsThroughTarget(authParm _sumanUseCase.AuthParams, result *systemResult) bool {
+	path, err := h.sumanProxy.SystemGetConnectionPath(authParm, result.systemID)
+	if err != nil {
+		log.Warn(fmt.Sprintf("unable to get connection path of system %v: %v", result.name, err))
+		return false
+	}
+	if strings.EqualFold(h.input.Target, h.genConfig.Suman.Server) {
+		return len(path) == 0
+	}
+	for _, hop := range path {
+		if hop.Position == 1 {
+			return strings.EqualFold(hop.Hostname, h.input.Target)
+		}
+	}
+	return false
+}
+
+// shellQuote quotes the value as a single shell word.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// printSummary prints the result per system and returns the number of failed systems.
+func (h *SystemRereg) printSummary(results []*systemResult) int {
+	failed := 0
+	fmt.Printf("%-50s %s\n", "system", "status")
+	for _, result := range results {
+		fmt.Printf("%-50s %s\n", result.name, result.status)
+		if result.err != nil {
+			fmt.Printf("    %v\n", result.err)
+			failed++
+		}
+	}
+	return failed
+}
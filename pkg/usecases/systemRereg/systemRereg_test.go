@@ -0,0 +1,116 @@
+package systemRereg
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/models/inputfile"
+	sumamodels "mlmtool/pkg/models/susemanager"
+	srr "mlmtool/pkg/models/systemRereg"
+	"mlmtool/pkg/testing/fakesuma"
+	"mlmtool/pkg/testing/testutil"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+)
+
+func TestMain(m *testing.M) {
+	checkinInterval = 10 * time.Millisecond
+	testutil.Main(m)
+}
+
+var scriptTarget = regexp.MustCompile(`(?m)^TARGET='(.*)'$`)
+
+func TestSystemRereg(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          srr.InputData
+		toServer       bool
+		bootstrapped   func(name string) bool
+		expectError    bool
+		expectedScript map[string]string
+	}{
+		{
+			name:           "system moved to new proxy",
+			input:          srr.InputData{Server: "web01.example.com", Target: "proxy-new.example.com", Bootstrap: "sles15.sh", BatchSize: 1},
+			expectedScript: map[string]string{"web01.example.com": "proxy-new.example.com"},
+		},
+		{
+			name:           "system moved to the server",
+			input:          srr.InputData{Server: "web01.example.com", Bootstrap: "sles15.sh", BatchSize: 1},
+			toServer:       true,
+			expectedScript: map[string]string{"web01.example.com": ""},
+		},
+		{
+			name:           "clients of a proxy moved in batches",
+			input:          srr.InputData{Proxy: "proxy-old.example.com", Target: "proxy-new.example.com", Bootstrap: "sles15.sh", BatchSize: 1},
+			expectedScript: map[string]string{"web01.example.com": "proxy-new.example.com", "web02.example.com": "proxy-new.example.com"},
+		},
+		{
+			name:           "system checked in through the old proxy",
+			input:          srr.InputData{Proxy: "proxy-old.example.com", Target: "proxy-new.example.com", Bootstrap: "sles15.sh", BatchSize: 2},
+			bootstrapped:   func(name string) bool { return name != "web02.example.com" },
+			expectError:    true,
+			expectedScript: map[string]string{"web01.example.com": "proxy-new.example.com", "web02.example.com": "proxy-new.example.com"},
+		},
+		{
+			name:           "target quoted in the script",
+			input:          srr.InputData{Server: "web01.example.com", Target: "proxy'; reboot; '", Bootstrap: "sles15.sh", BatchSize: 1},
+			expectError:    true,
+			expectedScript: map[string]string{"web01.example.com": `proxy'\''; reboot; '\''`},
+		},
+		{
+			name:        "unknown system",
+			input:       srr.InputData{Server: "web09.example.com", Target: "proxy-new.example.com", Bootstrap: "sles15.sh", BatchSize: 1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := fakesuma.New("admin", "secret")
+			defer fake.Close()
+			old := sumamodels.CustomDate(time.Now().Add(-time.Hour).UTC().Truncate(time.Second))
+			fake.AddSystem(fakesuma.System{Name: "proxy-old.example.com", LastCheckin: old})
+			fake.AddSystem(fakesuma.System{Name: "proxy-new.example.com", LastCheckin: old})
+			fake.AddSystem(fakesuma.System{Name: "web01.example.com", LastCheckin: old, ConnectionPath: []string{"proxy-old.example.com"}})
+			fake.AddSystem(fakesuma.System{Name: "web02.example.com", LastCheckin: old, ConnectionPath: []string{"proxy-old.example.com"}})
+			fake.RunScript = func(system *fakesuma.System, script string) {
+				system.LastCheckin = sumamodels.CustomDate(time.Now().Add(time.Minute).UTC().Truncate(time.Second))
+				if tt.bootstrapped != nil && !tt.bootstrapped(system.Name) {
+					return
+				}
+				system.ConnectionPath = nil
+				if target := scriptTarget.FindStringSubmatch(script); target[1] != fake.Host() {
+					system.ConnectionPath = []string{target[1]}
+				}
+			}
+			var genConfig inputfile.Config
+			genConfig.Suman.Server = fake.Host()
+			if tt.toServer {
+				tt.input.Target = fake.Host()
+				tt.expectedScript["web01.example.com"] = fake.Host()
+			}
+			proxy := fake.Proxy()
+			h := NewSystemRereg(proxy, _sumanUseCase.NewSuseManager(proxy, fake.Config()), 1, genConfig, tt.input)
+
+			err := h.SystemRereg()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			scripts := make(map[string]string)
+			for _, action := range fake.Actions(0) {
+				system, _ := fake.GetSystem(action.SystemID)
+				scripts[system.Name] = scriptTarget.FindStringSubmatch(action.Script)[1]
+			}
+			if len(tt.expectedScript) == 0 {
+				assert.Empty(t, scripts)
+				return
+			}
+			assert.Equal(t, tt.expectedScript, scripts)
+		})
+	}
+}
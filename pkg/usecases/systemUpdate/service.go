@@ -0,0 +1,5 @@
+package systemUpdate
+
+type ISystemUpdate interface {
+	SystemUpdate() error
+}
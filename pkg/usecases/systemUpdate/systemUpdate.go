@@ -0,0 +1,167 @@
+package systemUpdate
+
+import (
+	"errors"
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	su "mlmtool/pkg/models/systemUpdate"
+	"os"
+	"path/filepath"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/errorhandling"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+type SystemUpdate struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                su.InputData
+}
+
+func NewSystemUpdate(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, suseoperationtimeout int, genConfig inputfile.Config, input su.InputData) *SystemUpdate {
+	return &SystemUpdate{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// SystemUpdate updates a single system: it runs the pre-update scripts, applies all pending patches,
+// runs the post-update scripts, reboots the system when required and applies the highstate.
+// Each failing step is handled according to the error_handling section of the configuration.
+func (h *SystemUpdate) SystemUpdate() error {
+	log.Debug("SystemUpdate started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	systemID, err := h.validateSystemUpdate(authParm)
+	if err != nil {
+		return err
+	}
+	err = h.doSystemUpdate(authParm, systemID)
+	if err != nil {
+		return err
+	}
+	log.Info("SystemUpdate finished")
+	return nil
+}
+
+// validateSystemUpdate checks the server is given and known by SUSE Manager and returns its system id.
+func (h *SystemUpdate) validateSystemUpdate(authParm _sumanUseCase.AuthParams) (int, error) {
+	log.Debug("systemUpdate validateSystemUpdate started")
+	if len(h.input.Server) == 0 {
+		return 0, fmt.Errorf("server is mandatory")
+	}
+	systems, err := h.sumanProxy.SystemGetID(authParm, h.input.Server)
+	if err != nil {
+		return 0, err
+	}
+	if len(systems) == 0 {
+		return 0, fmt.Errorf("%v: %v", returnCodes.ErrSystemNotFound, h.input.Server)
+	}
+	if len(systems) > 1 {
+		return 0, fmt.Errorf("more than one system found with name %v", h.input.Server)
+	}
+	log.Debug("systemUpdate validateSystemUpdate finished")
+	return systems[0].ID, nil
+}
+
+// doSystemUpdate runs all update steps for the given system.
+func (h *SystemUpdate) doSystemUpdate(authParm _sumanUseCase.AuthParams, systemID int) error {
+	log.Debug("doSystemUpdate started")
+	policies := h.genConfig.ErrorHandling
+	err := h.runScripts(authParm, systemID, "pre_update")
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("applying updates on system %v", h.input.Server))
+	err = h.handle(policies.Update, "update", h.suse.UpdateSystem(authParm, systemID, h.suseoperationtimeout))
+	if err != nil {
+		return err
+	}
+	err = h.runScripts(authParm, systemID, "post_update")
+	if err != nil {
+		return err
+	}
+	rebootRequired, err := h.rebootRequired(authParm, systemID)
+	if err != nil {
+		return err
+	}
+	if rebootRequired {
+		log.Info(fmt.Sprintf("rebooting system %v", h.input.Server))
+		err = h.handle(policies.Reboot, "reboot", h.sumanProxy.SystemScheduleReboot(authParm, systemID, h.suseoperationtimeout))
+		if err != nil {
+			return err
+		}
+	}
+	log.Info(fmt.Sprintf("applying highstate on system %v", h.input.Server))
+	err = h.handle(policies.Configupdate, "highstate", h.sumanProxy.SystemScheduleApplyHighstate(authParm, systemID, h.suseoperationtimeout))
+	if err != nil {
+		return err
+	}
+	log.Debug("doSystemUpdate finished")
+	return nil
+}
+
+// runScripts runs the general and the server specific script for the given stage, when present.
+// The scripts are read from dirs.update_script_dir and are named <stage>.sh and <server>_<stage>.sh.
+func (h *SystemUpdate) runScripts(authParm _sumanUseCase.AuthParams, systemID int, stage string) error {
+	scriptDir := h.genConfig.Dirs.UpdateScriptDir
+	if len(scriptDir) == 0 {
+		return nil
+	}
+	for _, name := range []string{stage + ".sh", h.input.Server + "_" + stage + ".sh"} {
+		fileName := filepath.Join(scriptDir, name)
+		script, err := os.ReadFile(filepath.Clean(fileName))
+		if errors.Is(err, os.ErrNotExist) {
+			log.Debug(fmt.Sprintf("no script %v present", fileName))
+			continue
+		}
+		if err != nil {
+			err = h.handle(h.genConfig.ErrorHandling.Script, fmt.Sprintf("reading script %v", fileName), err)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		log.Info(fmt.Sprintf("running script %v on system %v", fileName, h.input.Server))
+		err = h.handle(h.genConfig.ErrorHandling.Script, fmt.Sprintf("script %v", fileName), h.sumanProxy.ScheduleScriptRun(authParm, systemID, h.suseoperationtimeout, string(script)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebootRequired checks if a reboot has been requested or SUSE Manager suggests a reboot for the system.
+func (h *SystemUpdate) rebootRequired(authParm _sumanUseCase.AuthParams, systemID int) (bool, error) {
+	if h.input.Reboot {
+		return true, nil
+	}
+	systems, err := h.sumanProxy.SystemListSuggestedReboot(authParm)
+	if err != nil {
+		return false, err
+	}
+	for _, system := range systems {
+		if system.ID == systemID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// handle applies the given error_handling policy, or the timeout_passed policy when the action ran into a timeout.
+func (h *SystemUpdate) handle(policy string, step string, err error) error {
+	return errorhandling.HandleAction(h.genConfig.ErrorHandling, policy, fmt.Sprintf("%v of system %v", step, h.input.Server), err)
+}
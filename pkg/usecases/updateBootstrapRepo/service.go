@@ -0,0 +1,5 @@
+package updateBootstrapRepo
+
+type IUpdateBootstrapRepo interface {
+	UpdateBootstrapRepo() error
+}
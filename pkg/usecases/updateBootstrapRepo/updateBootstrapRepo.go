@@ -0,0 +1,202 @@
+package updateBootstrapRepo
+
+import (
+	"fmt"
+	"mlmtool/pkg/models/inputfile"
+	ubr "mlmtool/pkg/models/updateBootstrapRepo"
+	"sort"
+	"strings"
+	"time"
+
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	"mlmtool/pkg/util/cmdexecutor"
+	log "mlmtool/pkg/util/logger"
+	returnCodes "mlmtool/pkg/util/returnCodes"
+)
+
+// maxCloneDepth limits how far the clone_original chain of a channel is followed
+const maxCloneDepth = 10
+
+// repoResult - outcome of the update of a single bootstrap repository
+type repoResult struct {
+	repo    string
+	channel string
+	status  string
+	output  []string
+	err     error
+}
+
+type UpdateBootstrapRepo struct {
+	sumanProxy           _sumanUseCase.IProxy
+	suse                 _sumanUseCase.ISuseManager
+	cmd                  cmdexecutor.ICMDExecutor
+	suseoperationtimeout int
+	genConfig            inputfile.Config
+	input                ubr.InputData
+}
+
+func NewUpdateBootstrapRepo(sumanProxy _sumanUseCase.IProxy, suse _sumanUseCase.ISuseManager, cmd cmdexecutor.ICMDExecutor, suseoperationtimeout int, genConfig inputfile.Config, input ubr.InputData) *UpdateBootstrapRepo {
+	return &UpdateBootstrapRepo{
+		sumanProxy:           sumanProxy,
+		suse:                 suse,
+		cmd:                  cmd,
+		suseoperationtimeout: suseoperationtimeout,
+		genConfig:            genConfig,
+		input:                input,
+	}
+}
+
+// UpdateBootstrapRepo recreates the bootstrap repository for every OS configured in bootstrap-repo.repos, or only
+// for the given one. The mapped channel has to exist and be synced, after which bootstrap-repo.command is run.
+// A summary per repository is printed. Returns an error when one or more repositories failed.
+func (h *UpdateBootstrapRepo) UpdateBootstrapRepo() error {
+	log.Debug("UpdateBootstrapRepo started")
+	sessionKey, err := h.sumanProxy.SumanLogin()
+	if err != nil {
+		log.Error(fmt.Sprintf("%v - error %v", returnCodes.ErrLoginSuseManager, err))
+		return err
+	}
+	var authParm _sumanUseCase.AuthParams
+	authParm.Host = h.genConfig.Suman.Server
+	authParm.SessionKey = sessionKey
+	repos, err := h.validateUpdateBootstrapRepo()
+	if err != nil {
+		return err
+	}
+	results := h.doUpdateBootstrapRepo(authParm, repos)
+	failed := h.printSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("update failed for %v of %v bootstrap repositories", failed, len(results))
+	}
+	log.Info("UpdateBootstrapRepo finished")
+	return nil
+}
+
+// validateUpdateBootstrapRepo checks the configuration and returns the sorted list of repositories to be updated.
+func (h *UpdateBootstrapRepo) validateUpdateBootstrapRepo() ([]string, error) {
+	log.Debug("updateBootstrapRepo validateUpdateBootstrapRepo started")
+	if len(strings.Fields(h.genConfig.BootstrapRepo.Command)) == 0 {
+		return nil, fmt.Errorf("bootstrap-repo.command is not configured")
+	}
+	if len(h.genConfig.BootstrapRepo.Repos) == 0 {
+		return nil, fmt.Errorf("no repositories configured in bootstrap-repo.repos")
+	}
+	var repos []string
+	for repo := range h.genConfig.BootstrapRepo.Repos {
+		if len(h.input.Repo) == 0 || strings.EqualFold(repo, h.input.Repo) {
+			repos = append(repos, repo)
+		}
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("repository %v is not configured in bootstrap-repo.repos", h.input.Repo)
+	}
+	sort.Strings(repos)
+	log.Debug("updateBootstrapRepo validateUpdateBootstrapRepo finished")
+	return repos, nil
+}
+
+// doUpdateBootstrapRepo creates the bootstrap repository for every given repository.
+func (h *UpdateBootstrapRepo) doUpdateBootstrapRepo(authParm _sumanUseCase.AuthParams, repos []string) []*repoResult {
+	log.Debug("doUpdateBootstrapRepo started")
+	var results []*repoResult
+	labels, err := h.listBootstrapLabels()
+	if err != nil {
+		for _, repo := range repos {
+			results = append(results, &repoResult{repo: repo, channel: h.genConfig.BootstrapRepo.Repos[repo], status: "failed", err: err})
+		}
+		return results
+	}
+	for _, repo := range repos {
+		result := &repoResult{repo: repo, channel: h.genConfig.BootstrapRepo.Repos[repo], status: "updated"}
+		results = append(results, result)
+		// the configuration keys are lowercased when read, the labels of mgr-create-bootstrap-repo are not
+		label, ok := labels[strings.ToLower(repo)]
+		if !ok {
+			result.status = "failed"
+			result.err = fmt.Errorf("%v is not a bootstrap repository known by mgr-create-bootstrap-repo", repo)
+			continue
+		}
+		err = h.checkChannel(authParm, result.channel)
+		if err != nil {
+			result.status = "skipped"
+			result.err = err
+			continue
+		}
+		log.Info(fmt.Sprintf("creating bootstrap repository %v from channel %v", label, result.channel))
+		result.output, err = h.runCommand("--create", label, "--with-custom-channels", "--with-parent-channel", result.channel, "--flush")
+		if err != nil {
+			result.status = "failed"
+			result.err = err
+		}
+	}
+	log.Debug("doUpdateBootstrapRepo finished")
+	return results
+}
+
+// listBootstrapLabels returns the labels known by mgr-create-bootstrap-repo, keyed by their lowercased label.
+func (h *UpdateBootstrapRepo) listBootstrapLabels() (map[string]string, error) {
+	output, err := h.runCommand("--list")
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	for _, line := range output {
+		// the list is printed as "<number>. <label>"
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasSuffix(fields[0], ".") {
+			continue
+		}
+		labels[strings.ToLower(fields[1])] = fields[1]
+	}
+	return labels, nil
+}
+
+// checkChannel checks the channel exists and has been synced. For cloned channels, like the ones created by
+// content lifecycle management, the last sync of the original channel is checked.
+func (h *UpdateBootstrapRepo) checkChannel(authParm _sumanUseCase.AuthParams, channel string) error {
+	exists, err := h.sumanProxy.ChannelSoftwareIsExisting(authParm, channel)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("channel %v doesn't exist", channel)
+	}
+	label := channel
+	for i := 0; i < maxCloneDepth; i++ {
+		details, err := h.sumanProxy.ChannelSoftwareGetDetails(authParm, label)
+		if err != nil {
+			return err
+		}
+		if len(details.CloneOriginal) == 0 {
+			if time.Time(details.YumrepoLastSync).IsZero() {
+				return fmt.Errorf("channel %v has not been synced", label)
+			}
+			return nil
+		}
+		label = details.CloneOriginal
+	}
+	return fmt.Errorf("unable to find the original channel of %v", channel)
+}
+
+// runCommand runs bootstrap-repo.command with the given arguments and returns its output.
+func (h *UpdateBootstrapRepo) runCommand(args ...string) ([]string, error) {
+	command := strings.Fields(h.genConfig.BootstrapRepo.Command)
+	return h.cmd.ExecuteCommand(command[0], append(command[1:], args...))
+}
+
+// printSummary prints the result per repository and returns the number of failed repositories.
+func (h *UpdateBootstrapRepo) printSummary(results []*repoResult) int {
+	failed := 0
+	fmt.Printf("%-30s %-60s %s\n", "repository", "channel", "status")
+	for _, result := range results {
+		fmt.Printf("%-30s %-60s %s\n", result.repo, result.channel, result.status)
+		if result.err != nil {
+			fmt.Printf("    %v\n", result.err)
+			failed++
+		}
+		for _, line := range result.output {
+			log.Debug(fmt.Sprintf("    %v: %v", result.repo, line))
+		}
+	}
+	return failed
+}
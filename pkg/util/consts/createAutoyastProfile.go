@@ -9,6 +9,7 @@ const DefaultAutoyastDir string = "/opt/autoyast"
 const AutoyastTypes string = "SL_SERVER-s154 SL_SERVER-s155 SL_SERVER-s156 SL_SERVER-s157 K3S_MGMT-mi52 K3S_MGMT-mi55 K3S_SERVER-mi52 K3S_SERVER-mi55 POD_SERVER-mi52 POD_SERVER-mi55 SUMAS_SERVER-sm43 dtag_server"
 const VirtType string = "none"
 const AutoyastDistribution string = "installFirstRun"
+const AutoyastInstallType string = "sles15generic"
 
 var dtagServerVars = map[string]interface{}{
 	"org":          1,
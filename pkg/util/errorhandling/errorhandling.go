@@ -0,0 +1,74 @@
+// Package errorhandling - handle errors according to the error_handling policies from the configuration
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mlmtool/pkg/models/inputfile"
+	_sumanUseCase "mlmtool/pkg/usecases/susemanager"
+	log "mlmtool/pkg/util/logger"
+)
+
+const (
+	// Fatal report error, exit
+	Fatal = "fatal"
+	// Error report error, continue
+	Error = "error"
+	// Warning report warning, continue
+	Warning = "warning"
+)
+
+// Handle - report the given error according to the given policy
+//
+// param: policy
+// param: step
+// param: err
+// return: the error when the policy is fatal or unknown, otherwise nil
+func Handle(policy string, step string, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch strings.ToLower(strings.TrimSpace(policy)) {
+	case Warning:
+		log.Warn(fmt.Sprintf("%s failed: %v", step, err))
+		return nil
+	case Error:
+		log.Error(fmt.Sprintf("%s failed: %v", step, err))
+		return nil
+	default:
+		log.Error(fmt.Sprintf("%s failed: %v", step, err))
+		return fmt.Errorf("%s failed: %w", step, err)
+	}
+}
+
+// HandleAction - report the error of an action according to the given policy, or to error_handling.timeout_passed
+// when the action ran into a timeout
+//
+// param: policies
+// param: policy
+// param: step
+// param: err
+// return: the error when the applied policy is fatal or unknown, otherwise nil
+func HandleAction(policies inputfile.ErrorHandling, policy string, step string, err error) error {
+	return Handle(ActionPolicy(policies, policy, err), step, err)
+}
+
+// ActionPolicy - the policy HandleAction applies to the error of an action
+//
+// param: policies
+// param: policy
+// param: err
+// return: Fatal, Error or Warning
+func ActionPolicy(policies inputfile.ErrorHandling, policy string, err error) string {
+	if errors.Is(err, _sumanUseCase.ErrActionTimeout) {
+		policy = policies.TimeoutPassed
+	}
+	switch policy = strings.ToLower(strings.TrimSpace(policy)); policy {
+	case Warning, Error:
+		return policy
+	default:
+		return Fatal
+	}
+}
@@ -17,26 +17,31 @@ import (
 //	error: when there is an error this will be returned, otherwise nil
 //	model.Config: configuration file data
 func ReadConfig(cfgFile string, appConfig interface{}) error {
+	// keys like the bootstrap-repo labels contain dots, so another delimiter than the default is needed
+	v := viper.NewWithOptions(viper.KeyDelimiter("::"))
 	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
+		v.SetConfigFile(cfgFile)
 	} else {
-		viper.AddConfigPath(".")
-		viper.SetConfigName("config")
+		v.AddConfigPath(".")
+		v.SetConfigName("config")
 		cfgFile = "./config.yaml"
 	}
-	viper.SetConfigType("yaml")
-	viper.AutomaticEnv()
+	v.SetConfigType("yaml")
+	v.AutomaticEnv()
 	fmt.Println("Using config file:", cfgFile)
 	if _, err := os.Stat(cfgFile); errors.Is(err, os.ErrNotExist) {
 		fmt.Println("Warning: No config file found. Using defaults and environment variables. %w", err)
 		return fmt.Errorf("no config file found")
 	}
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			fmt.Println("Warning: No config file found. Using defaults and environment variables.")
 		}
 	}
-	err := viper.Unmarshal(&appConfig, func(dc *mapstructure.DecoderConfig) {})
+	err := v.Unmarshal(&appConfig, func(dc *mapstructure.DecoderConfig) {
+		// the config structs are annotated with yaml tags, matching the keys in the configuration file
+		dc.TagName = "yaml"
+	})
 	if err != nil {
 		return fmt.Errorf("unable to decode config into struct: %w", err)
 	}
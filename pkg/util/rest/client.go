@@ -0,0 +1,210 @@
+// Package rest - rest api helper
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "mlmtool/pkg/util/logger"
+)
+
+const (
+	defaultTimeout      = 300 * time.Second
+	defaultBackoffBase  = time.Second
+	defaultBackoffMax   = 30 * time.Second
+	maxIdleConnsPerHost = 32
+)
+
+// ClientConfig - settings of an api client
+type ClientConfig struct {
+	// Insecure - skip the verification of the server certificate
+	Insecure bool
+	// CABundle - PEM file with additional CA certificates trusted besides the system pool
+	CABundle string
+	// Timeout - time limit of a single attempt, 0 for the default of 5 minutes
+	Timeout time.Duration
+	// RetryCount - number of retries after the first attempt
+	RetryCount int
+	// BackoffBase - wait before the first retry, doubled for every further retry
+	BackoffBase time.Duration
+	// BackoffMax - upper limit of the wait between retries
+	BackoffMax time.Duration
+}
+
+// Client - http client sharing one pooled transport for all requests. Safe for concurrent use.
+type Client struct {
+	http        *http.Client
+	retryCount  int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+}
+
+// NewClient - create a client with its own connection pool
+//
+// param: cfg
+// return: *Client, error
+func NewClient(cfg ClientConfig) (*Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.Insecure} // nolint:gosec
+	if len(cfg.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(filepath.Clean(cfg.CABundle))
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle %v: %w", cfg.CABundle, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %v", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	c := &Client{
+		http:        &http.Client{Transport: transport, Timeout: cfg.Timeout},
+		retryCount:  cfg.RetryCount,
+		backoffBase: cfg.BackoffBase,
+		backoffMax:  cfg.BackoffMax,
+	}
+	if c.http.Timeout == 0 {
+		c.http.Timeout = defaultTimeout
+	}
+	if c.backoffBase == 0 {
+		c.backoffBase = defaultBackoffBase
+	}
+	if c.backoffMax == 0 {
+		c.backoffMax = defaultBackoffMax
+	}
+	return c, nil
+}
+
+// Do - send the request, retrying network errors, 429 and 5xx responses with exponential backoff and jitter. Other
+// responses, including 4xx, are returned as they are. Requests that are not idempotent, like the POSTs scheduling
+// actions, are only retried on 429 and on errors before the request was written, as the server may have processed them.
+//
+// param: ctx
+// param: requestBody
+// param: method
+// param: url
+// param: header
+// return: *HTTPHelperStruct, error
+func (c *Client) Do(ctx context.Context, requestBody []byte, method string, url string, header map[string]string) (*HTTPHelperStruct, error) {
+	for attempt := 0; ; attempt++ {
+		output, retryAfter, err := c.send(ctx, requestBody, method, url, header)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if retryAfter < 0 || attempt >= c.retryCount {
+			return output, err
+		}
+		wait := c.backoff(attempt)
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		if err != nil {
+			log.Debug(fmt.Sprintf("request to %v failed: %v. Retry %v of %v in %v", url, err, attempt+1, c.retryCount, wait))
+		} else {
+			log.Debug(fmt.Sprintf("request to %v returned %v. Retry %v of %v in %v", url, output.StatusCode, attempt+1, c.retryCount, wait))
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send - one attempt of the request. retryAfter is negative when the response or error must not be retried.
+func (c *Client) send(ctx context.Context, requestBody []byte, method string, url string, header map[string]string) (*HTTPHelperStruct, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, -1, err
+	}
+	for k, v := range header {
+		req.Header.Add(k, v)
+	}
+	var written atomic.Bool
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) { written.Store(info.Err == nil) },
+	}))
+	retry := time.Duration(0)
+	if !idempotent(method) {
+		retry = -1
+	}
+	res, err := c.http.Do(req)
+	if err != nil {
+		if !written.Load() {
+			retry = 0
+		}
+		return nil, retry, err
+	}
+	defer res.Body.Close() // nolint:all
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, retry, err
+	}
+	output := &HTTPHelperStruct{
+		Body:       body,
+		StatusCode: res.StatusCode,
+		Cookies:    res.Cookies(),
+	}
+	if res.StatusCode != http.StatusTooManyRequests && (res.StatusCode < http.StatusInternalServerError || retry < 0) {
+		return output, -1, nil
+	}
+	retryAfter := time.Duration(0)
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	return output, retryAfter, nil
+}
+
+// idempotent - check if sending the request twice has the same effect as sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff - exponential wait before the next retry, randomized between half and the full interval
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.backoffMax
+	if attempt < 30 && c.backoffBase<<attempt < c.backoffMax {
+		wait = c.backoffBase << attempt
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+var (
+	sharedClients   = make(map[bool]*Client)
+	sharedClientsMu sync.Mutex
+)
+
+// sharedClient - pooled client used by HTTPHelper, one per certificate check setting
+func sharedClient(insecure bool, retryCount int) *Client {
+	sharedClientsMu.Lock()
+	defer sharedClientsMu.Unlock()
+	c, ok := sharedClients[insecure]
+	if !ok {
+		c, _ = NewClient(ClientConfig{Insecure: insecure})
+		sharedClients[insecure] = c
+	}
+	return &Client{http: c.http, retryCount: retryCount, backoffBase: c.backoffBase, backoffMax: c.backoffMax}
+}
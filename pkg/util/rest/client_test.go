@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/testing/testutil"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestClientDo(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		statuses       []int
+		retryCount     int
+		expectedCalls  int32
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "retry server errors",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  3,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "retry too many requests",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "no retry on client errors",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusNotFound, http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  1,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "retries exhausted",
+			method:         http.MethodGet,
+			statuses:       []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			retryCount:     2,
+			expectedCalls:  3,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "no retry of server errors on post",
+			method:         http.MethodPost,
+			statuses:       []int{http.StatusBadGateway, http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  1,
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "retry too many requests on post",
+			method:         http.MethodPost,
+			statuses:       []int{http.StatusTooManyRequests, http.StatusOK},
+			retryCount:     3,
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(call, len(tt.statuses)-1)])
+			}))
+			defer srv.Close()
+			client, err := NewClient(ClientConfig{RetryCount: tt.retryCount, BackoffBase: time.Millisecond, BackoffMax: 5 * time.Millisecond})
+			assert.NoError(t, err)
+
+			output, err := client.Do(t.Context(), []byte("{}"), tt.method, srv.URL, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, output.StatusCode)
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestClientDoNetworkError(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		refused       bool
+		expectError   bool
+		expectedCalls int32
+	}{
+		{
+			name:          "retry connection closed after request on get",
+			method:        http.MethodGet,
+			expectError:   true,
+			expectedCalls: 3,
+		},
+		{
+			name:          "no retry of connection closed after request on post",
+			method:        http.MethodPost,
+			expectError:   true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "retry connection refused on post",
+			method:        http.MethodPost,
+			refused:       true,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tt.refused {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				conn, _, err := w.(http.Hijacker).Hijack()
+				assert.NoError(t, err)
+				_ = conn.Close()
+			}))
+			defer srv.Close()
+			url := "http://" + srv.Listener.Addr().String()
+			if tt.refused {
+				addr := srv.Listener.Addr().String()
+				_ = srv.Listener.Close()
+				time.AfterFunc(20*time.Millisecond, func() {
+					listener, err := net.Listen("tcp", addr)
+					if assert.NoError(t, err) {
+						srv.Listener = listener
+						srv.Start()
+					}
+				})
+			} else {
+				srv.Start()
+			}
+			client, err := NewClient(ClientConfig{RetryCount: 2, BackoffBase: 20 * time.Millisecond, BackoffMax: 50 * time.Millisecond})
+			assert.NoError(t, err)
+
+			_, err = client.Do(t.Context(), []byte("{}"), tt.method, url, nil)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
@@ -3,7 +3,7 @@ package rest
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"fmt"
 	"io"
 	log "mlmtool/pkg/util/logger"
@@ -126,9 +126,8 @@ func (h *HTTPHelperStruct) String() string {
 	return fmt.Sprintf("Body: %s, Status Code: %d, Cookies: %v", h.Body, h.StatusCode, h.Cookies)
 }
 
-// HTTPHelper - rest api helper
+// HTTPHelper - rest api helper using a shared pooled client
 //
-// param: log
 // param: retrycount
 // param: requsetBody
 // param: method
@@ -137,40 +136,17 @@ func (h *HTTPHelperStruct) String() string {
 // param: header
 // return:
 func HTTPHelper(retrycount int, requsetBody []byte, method string, url string, insecure bool, header ...map[string]string) (*HTTPHelperStruct, error) {
-	reqBody := bytes.NewBuffer(requsetBody)
-
-	client := &http.Client{}
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, err
-	}
+	headers := make(map[string]string)
 	for k := range header {
 		for i, j := range header[k] {
-			req.Header.Add(i, j)
+			headers[i] = j
 		}
 	}
-	var res *http.Response
-	retry := 0
 	log.Debug("Connecting to: ", zap.Any("url", url))
-	for ok := true; ok; ok = (err != nil || retry == retrycount) {
-		res, err = client.Do(req)
-		if err != nil {
-			log.Debug("Retrying connect to: ", zap.Any("retry count", retry))
-			time.Sleep(time.Duration(retry*2) * time.Second)
-			if retry == retrycount {
-				log.Error("Failed connect to: ", zap.Any("url", url))
-				return nil, err
-			}
-		}
-		retry++
-	}
-	body, _ := io.ReadAll(res.Body)
-	defer res.Body.Close()
-	httpHelperStruct := HTTPHelperStruct{
-		Body:       body,
-		StatusCode: res.StatusCode,
-		Cookies:    res.Cookies(),
+	response, err := sharedClient(insecure, retrycount).Do(context.Background(), requsetBody, method, url, headers)
+	if err != nil {
+		log.Error("Failed connect to: ", zap.Any("url", url))
+		return nil, err
 	}
-	return &httpHelperStruct, nil
+	return response, nil
 }
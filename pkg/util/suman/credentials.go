@@ -0,0 +1,334 @@
+package suman
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"mlmtool/pkg/config"
+	"mlmtool/pkg/models/inputfile"
+	log "mlmtool/pkg/util/logger"
+)
+
+// Names of the credential sources in suman.credential_sources
+const (
+	SourceEnv      = "env"
+	SourceSystemd  = "systemd"
+	SourceFile     = "file"
+	SourceCommand  = "command"
+	SourceSpacecmd = "spacecmd"
+	SourceUyuni    = "uyunihub"
+	SourceConfig   = "config"
+)
+
+// Environment variables read by the env credential source
+const (
+	EnvServer   = "MLMTOOL_SUMAN_SERVER"
+	EnvUser     = "MLMTOOL_SUMAN_USER"
+	EnvPassword = "MLMTOOL_SUMAN_PASSWORD"
+)
+
+// Names of the systemd credentials, see LoadCredential= in systemd.exec(5)
+const (
+	SystemdUser     = "suman-user"
+	SystemdPassword = "suman-password"
+)
+
+// commandTimeout - time limit of the password command
+const commandTimeout = 30 * time.Second
+
+// DefaultCredentialSources - order of the credential sources when suman.credential_sources is not set. The plaintext
+// suman.password comes last, so it is only used when no other source is set up.
+var DefaultCredentialSources = []string{SourceEnv, SourceSystemd, SourceFile, SourceCommand, SourceConfig}
+
+// Credentials - credentials found by a source. Fields the source doesn't provide are empty.
+type Credentials struct {
+	Server   string `yaml:"server"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+}
+
+// CredentialSource - source of the SUSE Manager credentials
+type CredentialSource interface {
+	// Name - name of the source in suman.credential_sources
+	Name() string
+	// Credentials - credentials of the source, empty when the source is not set up
+	Credentials() (Credentials, error)
+}
+
+type envSource struct{}
+
+func (envSource) Name() string { return SourceEnv }
+
+func (envSource) Credentials() (Credentials, error) {
+	return Credentials{
+		Server:   os.Getenv(EnvServer),
+		User:     os.Getenv(EnvUser),
+		Password: os.Getenv(EnvPassword),
+	}, nil
+}
+
+// systemdSource - credentials passed by systemd in $CREDENTIALS_DIRECTORY
+type systemdSource struct{}
+
+func (systemdSource) Name() string { return SourceSystemd }
+
+func (systemdSource) Credentials() (Credentials, error) {
+	var creds Credentials
+	dir := os.Getenv("CREDENTIALS_DIRECTORY")
+	if len(dir) == 0 {
+		return creds, nil
+	}
+	var err error
+	if creds.User, err = readCredential(filepath.Join(dir, SystemdUser)); err != nil {
+		return creds, err
+	}
+	creds.Password, err = readCredential(filepath.Join(dir, SystemdPassword))
+	return creds, err
+}
+
+// readCredential - content of a systemd credential, empty when it is not passed
+func readCredential(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// fileSource - yaml file with the keys server, user and password, readable by the owner only
+type fileSource struct {
+	path string
+}
+
+func (fileSource) Name() string { return SourceFile }
+
+func (s fileSource) Credentials() (Credentials, error) {
+	var creds Credentials
+	if len(s.path) == 0 {
+		return creds, nil
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return creds, err
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return creds, fmt.Errorf("credentials file %v must not be accessible by other users, mode is %v", s.path, info.Mode().Perm())
+	}
+	data, err := os.ReadFile(filepath.Clean(s.path))
+	if err != nil {
+		return creds, err
+	}
+	if err := yaml.Unmarshal(data, &creds); err != nil {
+		return creds, fmt.Errorf("unable to parse credentials file %v: %w", s.path, err)
+	}
+	return creds, nil
+}
+
+// commandSource - password printed by a command like "pass show mlm/admin", run without a shell
+type commandSource struct {
+	command string
+}
+
+func (commandSource) Name() string { return SourceCommand }
+
+func (s commandSource) Credentials() (Credentials, error) {
+	var creds Credentials
+	args := strings.Fields(s.command)
+	if len(args) == 0 {
+		return creds, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) // nolint:gosec
+	var out, stdErr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stdErr
+	if err := cmd.Run(); err != nil {
+		return creds, fmt.Errorf("password command %v failed: %v %v", args[0], err, strings.TrimSpace(stdErr.String()))
+	}
+	// like pass, the password is the first line of the output
+	creds.Password = strings.TrimSpace(strings.SplitN(out.String(), "\n", 2)[0])
+	return creds, nil
+}
+
+// spacecmdSource - spacecmd config of the user
+type spacecmdSource struct {
+	path string
+}
+
+func (spacecmdSource) Name() string { return SourceSpacecmd }
+
+func (s spacecmdSource) Credentials() (Credentials, error) {
+	creds, err := readSpacecmd(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return creds, nil
+	}
+	return creds, err
+}
+
+// uyuniSource - uyuni hub config. The hubmaster is not used as server, as it is the primary of the hub.
+type uyuniSource struct {
+	path string
+}
+
+func (uyuniSource) Name() string { return SourceUyuni }
+
+func (s uyuniSource) Credentials() (Credentials, error) {
+	creds, _, err := readUyuni(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return creds, nil
+	}
+	return creds, err
+}
+
+// configSource - plaintext suman.user and suman.password of the configuration file
+type configSource struct {
+	suman inputfile.Suman
+}
+
+func (configSource) Name() string { return SourceConfig }
+
+func (s configSource) Credentials() (Credentials, error) {
+	if len(s.suman.Password) > 0 {
+		log.Warn("using the plaintext suman.password of the configuration file, consider another suman.credential_sources entry")
+	}
+	return Credentials{User: s.suman.User, Password: s.suman.Password}, nil
+}
+
+// NewCredentialSources - credential sources in the order of suman.credential_sources, or DefaultCredentialSources
+// when it is not set
+//
+// param: suman
+// return: []CredentialSource, error
+func NewCredentialSources(suman inputfile.Suman) ([]CredentialSource, error) {
+	names := suman.CredentialSources
+	if len(names) == 0 {
+		names = DefaultCredentialSources
+	}
+	defaults := config.New("credentials", false)
+	var sources []CredentialSource
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case SourceEnv:
+			sources = append(sources, envSource{})
+		case SourceSystemd:
+			sources = append(sources, systemdSource{})
+		case SourceFile:
+			sources = append(sources, fileSource{path: suman.CredentialsFile})
+		case SourceCommand:
+			sources = append(sources, commandSource{command: suman.PasswordCommand})
+		case SourceSpacecmd:
+			sources = append(sources, spacecmdSource{path: defaults.FileSpacecmd})
+		case SourceUyuni:
+			sources = append(sources, uyuniSource{path: defaults.FileUyuni})
+		case SourceConfig:
+			sources = append(sources, configSource{suman: suman})
+		default:
+			return nil, fmt.Errorf("unknown credential source %v in suman.credential_sources", name)
+		}
+	}
+	return sources, nil
+}
+
+// ResolveCredentials - ask the sources in order until user and password are known. Each field is taken from the
+// first source providing it.
+//
+// param: sources
+// return: Credentials, error
+func ResolveCredentials(sources []CredentialSource) (Credentials, error) {
+	var creds Credentials
+	var names []string
+	for _, source := range sources {
+		if len(creds.User) > 0 && len(creds.Password) > 0 {
+			break
+		}
+		names = append(names, source.Name())
+		found, err := source.Credentials()
+		if err != nil {
+			return creds, fmt.Errorf("credential source %v: %w", source.Name(), err)
+		}
+		if len(creds.Server) == 0 && len(found.Server) > 0 {
+			creds.Server = found.Server
+		}
+		if len(creds.User) == 0 && len(found.User) > 0 {
+			log.Debug(fmt.Sprintf("user taken from credential source %v", source.Name()))
+			creds.User = found.User
+		}
+		if len(creds.Password) == 0 && len(found.Password) > 0 {
+			log.Debug(fmt.Sprintf("password taken from credential source %v", source.Name()))
+			creds.Password = found.Password
+		}
+	}
+	if len(creds.User) == 0 || len(creds.Password) == 0 {
+		return creds, fmt.Errorf("no user and password found in the credential sources %v", strings.Join(names, ", "))
+	}
+	return creds, nil
+}
+
+// readSpacecmd - username, password and server of a spacecmd config
+func readSpacecmd(fileName string) (Credentials, error) {
+	var creds Credentials
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return creds, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		l := strings.SplitN(scanner.Text(), "=", 2) // Use SplitN to avoid index out of range
+		if len(l) < 2 {
+			continue // Skip malformed lines
+		}
+		switch strings.TrimSpace(l[0]) {
+		case "server":
+			creds.Server = strings.TrimSpace(l[1])
+		case "username":
+			creds.User = strings.TrimSpace(l[1])
+		case "password":
+			creds.Password = strings.TrimSpace(l[1])
+		}
+	}
+	return creds, scanner.Err()
+}
+
+// readUyuni - user and password of an uyuni hub config, and its hubmaster
+func readUyuni(fileName string) (Credentials, string, error) {
+	var creds Credentials
+	var hubmaster string
+	file, err := os.Open(filepath.Clean(fileName))
+	if err != nil {
+		return creds, hubmaster, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		l := strings.SplitN(scanner.Text(), ":", 2) // Use SplitN to avoid index out of range
+		if len(l) < 2 {
+			continue // Skip malformed lines
+		}
+		switch strings.TrimSpace(l[0]) {
+		case "user":
+			creds.User = strings.TrimSpace(l[1])
+		case "password":
+			creds.Password = strings.TrimSpace(l[1])
+		case "hubmaster":
+			hubmaster = strings.TrimSpace(l[1])
+		}
+	}
+	return creds, hubmaster, scanner.Err()
+}
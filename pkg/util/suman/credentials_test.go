@@ -0,0 +1,161 @@
+package suman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mlmtool/pkg/models/inputfile"
+	"mlmtool/pkg/testing/testutil"
+)
+
+func TestMain(m *testing.M) {
+	testutil.Main(m)
+}
+
+func TestResolveCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		suman         inputfile.Suman
+		env           map[string]string
+		files         map[string]string
+		fileMode      os.FileMode
+		expectError   bool
+		expectedCreds Credentials
+	}{
+		{
+			name:          "plaintext config",
+			suman:         inputfile.Suman{User: "admin", Password: "config"},
+			expectedCreds: Credentials{User: "admin", Password: "config"},
+		},
+		{
+			name:          "environment before config",
+			suman:         inputfile.Suman{User: "admin", Password: "config"},
+			env:           map[string]string{EnvPassword: "env"},
+			expectedCreds: Credentials{User: "admin", Password: "env"},
+		},
+		{
+			name:          "configured order",
+			suman:         inputfile.Suman{User: "admin", Password: "config", CredentialSources: []string{SourceConfig, SourceEnv}},
+			env:           map[string]string{EnvUser: "operator", EnvPassword: "env"},
+			expectedCreds: Credentials{User: "admin", Password: "config"},
+		},
+		{
+			name:          "systemd credentials",
+			suman:         inputfile.Suman{CredentialSources: []string{SourceSystemd}},
+			files:         map[string]string{"creds/" + SystemdUser: "admin\n", "creds/" + SystemdPassword: "systemd\n"},
+			expectedCreds: Credentials{User: "admin", Password: "systemd"},
+		},
+		{
+			name:          "credentials file",
+			suman:         inputfile.Suman{CredentialSources: []string{SourceFile}, CredentialsFile: "credentials.yaml"},
+			files:         map[string]string{"credentials.yaml": "server: suma.example.com\nuser: admin\npassword: file\n"},
+			fileMode:      0o600,
+			expectedCreds: Credentials{Server: "suma.example.com", User: "admin", Password: "file"},
+		},
+		{
+			name:        "credentials file readable by others",
+			suman:       inputfile.Suman{CredentialSources: []string{SourceFile}, CredentialsFile: "credentials.yaml"},
+			files:       map[string]string{"credentials.yaml": "user: admin\npassword: file\n"},
+			fileMode:    0o644,
+			expectError: true,
+		},
+		{
+			name:          "password command",
+			suman:         inputfile.Suman{User: "admin", CredentialSources: []string{SourceCommand, SourceConfig}, PasswordCommand: "echo command"},
+			expectedCreds: Credentials{User: "admin", Password: "command"},
+		},
+		{
+			name:        "failing password command",
+			suman:       inputfile.Suman{User: "admin", CredentialSources: []string{SourceCommand, SourceConfig}, PasswordCommand: "false"},
+			expectError: true,
+		},
+		{
+			name:        "unknown source",
+			suman:       inputfile.Suman{CredentialSources: []string{"vault"}},
+			expectError: true,
+		},
+		{
+			name:        "no password",
+			suman:       inputfile.Suman{User: "admin"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv(EnvServer, "")
+			t.Setenv(EnvUser, "")
+			t.Setenv(EnvPassword, "")
+			t.Setenv("CREDENTIALS_DIRECTORY", filepath.Join(dir, "creds"))
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+				assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+				if tt.fileMode != 0 {
+					assert.NoError(t, os.Chmod(path, tt.fileMode))
+				}
+			}
+			if len(tt.suman.CredentialsFile) > 0 {
+				tt.suman.CredentialsFile = filepath.Join(dir, tt.suman.CredentialsFile)
+			}
+
+			sources, err := NewCredentialSources(tt.suman)
+			if err == nil {
+				var creds Credentials
+				creds, err = ResolveCredentials(sources)
+				if !tt.expectError {
+					assert.Equal(t, tt.expectedCreds, creds)
+				}
+			}
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigFileSources(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        func(path string) CredentialSource
+		content       string
+		expectedCreds Credentials
+	}{
+		{
+			name:          "spacecmd",
+			source:        func(path string) CredentialSource { return spacecmdSource{path: path} },
+			content:       "[spacecmd]\nserver=suma.example.com\nusername=admin\npassword=spacecmd\n",
+			expectedCreds: Credentials{Server: "suma.example.com", User: "admin", Password: "spacecmd"},
+		},
+		{
+			name:          "uyuni hub",
+			source:        func(path string) CredentialSource { return uyuniSource{path: path} },
+			content:       "hubmaster: hub.example.com\nuser: admin\npassword: uyuni\n",
+			expectedCreds: Credentials{User: "admin", Password: "uyuni"},
+		},
+		{
+			name:   "missing spacecmd config",
+			source: func(path string) CredentialSource { return spacecmdSource{path: path + ".missing"} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			creds, err := tt.source(path).Credentials()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCreds, creds)
+		})
+	}
+}
@@ -1,11 +1,8 @@
 package suman
 
 import (
-	"bufio"
 	"bytes"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -29,28 +26,12 @@ func execHostname() (*bytes.Buffer, *bytes.Buffer, error) {
 // GetCredentials retrieves credentials needed for SUSE Manager Server primary
 func GetCredentials(fileName string, runCommands ...CommandRunner) (_sumanUseCase.SumanConfig, error) {
 	var sumancfg _sumanUseCase.SumanConfig
-	sumancfg.Insecure = true
-	fileName = filepath.Clean(fileName)
-
-	file, err := os.Open(fileName)
+	creds, err := readSpacecmd(fileName)
 	if err != nil {
 		return sumancfg, errors.New(returnCodes.ErrOpeningFile)
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		l := strings.SplitN(scanner.Text(), "=", 2) // Use SplitN to avoid index out of range
-		if len(l) < 2 {
-			continue // Skip malformed lines
-		}
-		switch strings.TrimSpace(l[0]) {
-		case "username":
-			sumancfg.Login = strings.TrimSpace(l[1])
-		case "password":
-			sumancfg.Password = strings.TrimSpace(l[1])
-		}
-	}
+	sumancfg.Login = creds.User
+	sumancfg.Password = creds.Password
 
 	runCommand := execHostname
 	if len(runCommands) > 0 {
@@ -77,29 +58,12 @@ func GetCredentials(fileName string, runCommands ...CommandRunner) (_sumanUseCas
 // GetCredentialsUyuni retrieves credentials needed for SUSE Manager Server secondary servers
 func GetCredentialsUyuni(fileName string) (_sumanUseCase.SumanConfig, error) {
 	var sumancfg _sumanUseCase.SumanConfig
-	sumancfg.Insecure = true
-	fileName = filepath.Clean(fileName)
-
-	file, err := os.Open(fileName)
+	creds, hubmaster, err := readUyuni(fileName)
 	if err != nil {
 		return sumancfg, errors.New(returnCodes.ErrOpeningFile)
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		l := strings.SplitN(scanner.Text(), ":", 2) // Use SplitN to avoid index out of range
-		if len(l) < 2 {
-			continue // Skip malformed lines
-		}
-		switch strings.TrimSpace(l[0]) {
-		case "user":
-			sumancfg.Login = strings.TrimSpace(l[1])
-		case "password":
-			sumancfg.Password = strings.TrimSpace(l[1])
-		case "hubmaster":
-			sumancfg.Host = strings.TrimSpace(l[1])
-		}
-	}
+	sumancfg.Login = creds.User
+	sumancfg.Password = creds.Password
+	sumancfg.Host = hubmaster
 	return sumancfg, nil
 }